@@ -3,14 +3,21 @@ module github.com/polaris-contrib/store-postgresql
 go 1.21
 
 require (
+	github.com/jackc/pgx/v5 v5.6.0
 	github.com/polarismesh/polaris v1.18.1
+	github.com/prometheus/client_golang v1.18.0
 	github.com/smartystreets/goconvey v1.8.1
 )
 
 require (
 	github.com/dlclark/regexp2 v1.10.0 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
+	github.com/jackc/puddle/v2 v2.2.1 // indirect
 	github.com/matttproud/golang_protobuf_extensions/v2 v2.0.0 // indirect
 	github.com/smarty/assertions v1.15.0 // indirect
+	golang.org/x/crypto v0.21.0 // indirect
+	golang.org/x/sync v0.6.0 // indirect
 )
 
 require (
@@ -26,7 +33,6 @@ require (
 	github.com/lib/pq v1.10.9
 	github.com/natefinch/lumberjack v2.0.0+incompatible // indirect
 	github.com/polarismesh/specification v1.5.2-0.20240722103923-1d9990d6f555
-	github.com/prometheus/client_golang v1.18.0 // indirect
 	github.com/prometheus/client_model v0.5.0 // indirect
 	github.com/prometheus/common v0.45.0 // indirect
 	github.com/prometheus/procfs v0.12.0 // indirect
@@ -21,7 +21,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -42,14 +41,16 @@ const (
 type adminStore struct {
 	master  *BaseDB
 	leStore LeaderElectionStore
+	history *leaderHistoryStore
 	leMap   map[string]*leaderElectionStateMachine
 	mutex   sync.Mutex
 }
 
-func newAdminStore(master *BaseDB) *adminStore {
+func newAdminStore(master *BaseDB, backend string) *adminStore {
 	return &adminStore{
 		master:  master,
-		leStore: &leaderElectionStore{master: master},
+		leStore: newLeaderElectionStore(master, backend),
+		history: &leaderHistoryStore{master: master},
 		leMap:   make(map[string]*leaderElectionStateMachine),
 	}
 }
@@ -102,7 +103,7 @@ func (l *leaderElectionStore) GetVersion(key string) (int64, error) {
 	mainStr := "select version from leader_election where elect_key = $1"
 
 	var count int64
-	err := l.master.DB.QueryRow(mainStr, key).Scan(&count)
+	err := l.master.QueryRow("leaderElectionStore.GetVersion", mainStr, key).Scan(&count)
 	if err != nil {
 		log.Errorf("[Store][database] get version (%s), err: %s", key, err.Error())
 	}
@@ -118,7 +119,9 @@ func (l *leaderElectionStore) CompareAndSwapVersion(key string, curVersion int64
 	err := l.master.processWithTransaction("compareAndSwapVersion", func(tx *BaseTx) error {
 		log.Debugf("[Store][database] compare and swap version (%s, %d, %d, %s)", key, curVersion, newVersion, leader)
 
-		stmt, err := tx.Prepare("update leader_election set leader = $1, version = $2 where elect_key = $3 and version = $4")
+		// 心跳使用数据库时钟刷新 mtime，与 CheckMtimeExpired 的 now() 保持同一时钟
+		stmt, err := tx.Prepare("update leader_election set leader = $1, version = $2, mtime = now() " +
+			"where elect_key = $3 and version = $4")
 		if err != nil {
 			return store.Error(err)
 		}
@@ -147,34 +150,34 @@ func (l *leaderElectionStore) CompareAndSwapVersion(key string, curVersion int64
 }
 
 // CheckMtimeExpired check last modify time expired
+// 租期在数据库侧按 now() 计算，各节点之间以及节点与数据库之间的时钟偏差不影响 leader 的存活判断
 func (l *leaderElectionStore) CheckMtimeExpired(key string, leaseTime int32) (string, bool, error) {
 	log.Debugf("[Store][database] check mtime expired (%s, %d)", key, leaseTime)
 
-	mainStr := "select leader, mtime from leader_election where elect_key = $1"
+	mainStr := "select leader, now() - mtime > make_interval(secs => $2) from leader_election where elect_key = $1"
 
 	var (
-		leader string
-		mtime  time.Time
+		leader  string
+		expired bool
 	)
 
-	err := l.master.DB.QueryRow(mainStr, key).Scan(&leader, &mtime)
+	err := l.master.QueryRow("leaderElectionStore.CheckMtimeExpired", mainStr, key, leaseTime).Scan(&leader, &expired)
 	if err != nil {
 		log.Errorf("[Store][database] check mtime expired (%s), err: %s", key, err.Error())
 	}
 
-	diffTime := CurrDiffTimeSecond(mtime)
-
-	return leader, int32(diffTime) > leaseTime, store.Error(err)
+	return leader, expired, store.Error(err)
 }
 
 func (l *leaderElectionStore) ListLeaderElections() ([]*model.LeaderElection, error) {
 	log.Info("[Store][database] list leader election")
 	mainStr := "SELECT elect_key, leader, " +
 		"CAST(EXTRACT(EPOCH FROM ctime) AS INTEGER) AS ctime, " +
-		"CAST(EXTRACT(EPOCH FROM mtime) AS INTEGER) AS mtime " +
+		"CAST(EXTRACT(EPOCH FROM mtime) AS INTEGER) AS mtime, " +
+		"now() - mtime <= make_interval(secs => $1) AS valid " +
 		"FROM leader_election"
 
-	rows, err := l.master.Query(mainStr)
+	rows, err := l.master.Query("leaderElectionStore.ListLeaderElections", mainStr, LeaseTime)
 	if err != nil {
 		log.Errorf("[Store][database] list leader election query err: %s", err.Error())
 		return nil, store.Error(err)
@@ -197,7 +200,8 @@ func fetchLeaderElectionRows(rows *sql.Rows) ([]*model.LeaderElection, error) {
 			&space.ElectKey,
 			&space.Host,
 			&space.Ctime,
-			&space.Mtime)
+			&space.Mtime,
+			&space.Valid)
 		if err != nil {
 			log.Errorf("[Store][database] fetch leader election rows scan err: %s", err.Error())
 			return nil, err
@@ -205,7 +209,6 @@ func fetchLeaderElectionRows(rows *sql.Rows) ([]*model.LeaderElection, error) {
 
 		space.CreateTime = time.Unix(space.Ctime, 0)
 		space.ModifyTime = time.Unix(space.Mtime, 0)
-		space.Valid = checkLeaderValid(space.Mtime)
 		out = append(out, space)
 	}
 	if err := rows.Err(); err != nil {
@@ -216,11 +219,6 @@ func fetchLeaderElectionRows(rows *sql.Rows) ([]*model.LeaderElection, error) {
 	return out, nil
 }
 
-func checkLeaderValid(mtime int64) bool {
-	delta := time.Now().Unix() - mtime
-	return delta <= LeaseTime
-}
-
 type leaderElectionStateMachine struct {
 	electKey         string
 	leStore          LeaderElectionStore
@@ -231,6 +229,10 @@ type leaderElectionStateMachine struct {
 	releaseSignal    int32
 	releaseTickLimit int32
 	leader           string
+	// fencingToken 成为 leader 时的选举版本号，每个任期都大于之前任意任期的值
+	fencingToken int64
+	// history 记录角色变更，为空时不记录
+	history *leaderHistoryStore
 }
 
 // isLeader 判断是领导者
@@ -255,6 +257,7 @@ func (le *leaderElectionStateMachine) mainLoop() {
 		case <-le.ctx.Done():
 			log.Infof("[Store][database] leader election stopped (%s)", le.electKey)
 			le.changeToFollower("")
+			le.release()
 			return
 		}
 	}
@@ -274,6 +277,7 @@ func (le *leaderElectionStateMachine) tick() {
 		if shouldRelease {
 			log.Infof("[Store][database] release leader election (%s)", le.electKey)
 			le.changeToFollower("")
+			le.release()
 			le.setReleaseTickLimit()
 			return
 		}
@@ -296,9 +300,18 @@ func (le *leaderElectionStateMachine) tick() {
 		return
 	}
 	if !dead {
-		// 自己之前是 leader，并且租期还没过，调整自己为 leader
+		// 自己之前是 leader，并且租期还没过，重新竞选拿到新的 fencing token 后调整自己为 leader
 		if leader == utils.LocalHost {
-			le.changeToLeader()
+			success, err := le.elect()
+			if err != nil {
+				log.Errorf("[Store][database] re-elect leader err (%s), stay follower state (%s)", err.Error(),
+					le.electKey)
+				return
+			}
+			if success {
+				le.changeToLeader()
+				return
+			}
 		}
 		// leader 信息出现变化，发布leader信息变化通知
 		if le.leader != leader {
@@ -339,19 +352,49 @@ func (le *leaderElectionStateMachine) setReleaseTickLimit() {
 // changeToLeader 更新为leader
 func (le *leaderElectionStateMachine) changeToLeader() {
 	log.Infof("[Store][database] change from follower to leader (%s)", le.electKey)
+	// 重新竞选得到新的任期时 fencing token 发生变化，同样记录
+	changed := !le.isLeader() || atomic.LoadInt64(&le.fencingToken) != le.version
+	atomic.StoreInt64(&le.fencingToken, le.version)
 	atomic.StoreInt32(&le.leaderFlag, 1)
 	le.leader = utils.LocalHost
+	if changed {
+		le.recordHistory(LeaderRole)
+	}
 	le.publishLeaderChangeEvent()
 }
 
 // changeToFollower 变更为追随者
 func (le *leaderElectionStateMachine) changeToFollower(leader string) {
 	log.Infof("[Store][database] change from leader to follower (%s)", le.electKey)
+	// 只记录 leader 变更为 follower，启动时以及观察到其他节点之间的 leader 变化时不记录
+	changed := le.isLeader()
 	atomic.StoreInt32(&le.leaderFlag, 0)
 	le.leader = leader
+	if changed {
+		le.recordHistory(FollowerRole)
+	}
 	le.publishLeaderChangeEvent()
 }
 
+// recordHistory 持久化角色变更
+func (le *leaderElectionStateMachine) recordHistory(role string) {
+	if le.history == nil {
+		return
+	}
+	le.history.record(le.electKey, role, le.leader, atomic.LoadInt64(&le.fencingToken))
+}
+
+// release 通知支持主动释放的选举后端放弃选举锁
+func (le *leaderElectionStateMachine) release() {
+	releaser, ok := le.leStore.(leaderElectionReleaser)
+	if !ok {
+		return
+	}
+	if err := releaser.ReleaseLeaderElection(le.electKey); err != nil {
+		log.Errorf("[Store][database] release leader election (%s) err: %s", le.electKey, err.Error())
+	}
+}
+
 // publishLeaderChangeEvent 写入事件值
 func (le *leaderElectionStateMachine) publishLeaderChangeEvent() {
 	_ = eventhub.Publish(eventhub.LeaderChangeEventTopic, store.LeaderChangeEvent{
@@ -418,6 +461,7 @@ func (m *adminStore) StartLeaderElection(key string) error {
 		cancel:           cancel,
 		releaseSignal:    0,
 		releaseTickLimit: 0,
+		history:          m.history,
 	}
 	err := le.leStore.CreateLeaderElection(key)
 	if err != nil {
@@ -455,6 +499,28 @@ func (m *adminStore) IsLeader(key string) bool {
 	return le.isLeaderAtomic()
 }
 
+// FencingToken 返回本节点当前任期的 fencing token，本节点不是 leader 时返回 false
+// token 随每次成功的选举单调递增，下游可以拒绝携带比已见过的 token 更小的请求，屏蔽已经失去领导权的旧 leader
+func (m *adminStore) FencingToken(key string) (int64, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	le, ok := m.leMap[key]
+	if !ok {
+		return 0, false
+	}
+	token := atomic.LoadInt64(&le.fencingToken)
+	if !le.isLeaderAtomic() {
+		return 0, false
+	}
+	return token, true
+}
+
+// ListLeaderElectionHistory 查询选举键在 [start, end) 时间范围内的角色变更记录，零值的时间表示不限制
+func (m *adminStore) ListLeaderElectionHistory(key string, start, end time.Time) ([]*LeaderElectionHistory, error) {
+	return m.history.ListLeaderElectionHistory(key, start, end)
+}
+
 // ListLeaderElections leader选举列表
 func (m *adminStore) ListLeaderElections() ([]*model.LeaderElection, error) {
 	return m.leStore.ListLeaderElections()
@@ -478,88 +544,21 @@ func (m *adminStore) ReleaseLeaderElection(key string) error {
 // BatchCleanDeletedInstances 批量删除实例
 func (m *adminStore) BatchCleanDeletedInstances(timeout time.Duration, batchSize uint32) (uint32, error) {
 	log.Infof("[Store][database] batch clean soft deleted instances(%d)", batchSize)
+	return m.batchCleanDeleted("instance", timeout, batchSize)
+}
 
-	var rowsAffected int64
-	err := m.master.processWithTransaction("batchCleanDeletedInstances", func(tx *BaseTx) error {
-		// 查询出需要清理的实例 ID 信息
-		loadWaitDel := "SELECT id FROM instance WHERE flag = 1 AND " +
-			"mtime <= $1 limit $2"
-		diffTime := GetCurrentSsTimestamp() - int64(timeout.Seconds())
-		rows, err := tx.Query(loadWaitDel, UnixSecondToTime(diffTime), batchSize)
-		if err != nil {
-			log.Errorf("[Store][database] batch clean soft deleted instances(%d), err: %s", batchSize, err.Error())
-			return store.Error(err)
-		}
-		waitDelIds := make([]interface{}, 0, batchSize)
-		defer func() {
-			_ = rows.Close()
-		}()
-
-		placeholders := make([]string, 0, batchSize)
-		idx := 1
-		for rows.Next() {
-			var id string
-			if err := rows.Scan(&id); err != nil {
-				log.Errorf("[Store][database] scan deleted instances id, err: %s", err.Error())
-				return store.Error(err)
-			}
-			waitDelIds = append(waitDelIds, id)
-			placeholders = append(placeholders, fmt.Sprintf("$%d", idx))
-		}
-		if len(waitDelIds) == 0 {
-			return nil
-		}
-		inSql := strings.Join(placeholders, ",")
-
-		cleanMetaStr := fmt.Sprintf("DELETE FROM instance_metadata WHERE id IN (%s)", inSql)
-		stmt, err := tx.Prepare(cleanMetaStr)
-		if err != nil {
-			return store.Error(err)
-		}
-		if _, err := stmt.Exec(waitDelIds...); err != nil {
-			log.Errorf("[Store][database] batch clean soft deleted instances(%d), err: %s", batchSize, err.Error())
-			return store.Error(err)
-		}
-
-		cleanCheckStr := fmt.Sprintf("DELETE FROM health_check WHERE id IN (%s)", inSql)
-		stmtChk, err := tx.Prepare(cleanCheckStr)
-		if err != nil {
-			return store.Error(err)
-		}
-		if _, err := stmtChk.Exec(waitDelIds...); err != nil {
-			log.Errorf("[Store][database] batch clean soft deleted instances(%d), err: %s", batchSize, err.Error())
-			return store.Error(err)
-		}
-
-		cleanInsStr := fmt.Sprintf("DELETE FROM instance WHERE flag = 1 AND id IN (%s)", inSql)
-		stmtRet, err := tx.Prepare(cleanInsStr)
-		if err != nil {
-			return store.Error(err)
-		}
-		result, err := stmtRet.Exec(waitDelIds...)
-		if err != nil {
-			log.Errorf("[Store][database] batch clean soft deleted instances(%d), err: %s", batchSize, err.Error())
-			return store.Error(err)
-		}
-
-		tRows, err := result.RowsAffected()
-		if err != nil {
-			log.Warnf("[Store][database] batch clean soft deleted instances(%d), get RowsAffected err: %s",
-				batchSize, err.Error())
-			return store.Error(err)
-		}
-
-		if err := tx.Commit(); err != nil {
-			log.Errorf("[Store][database] batch clean soft deleted instances(%d) commit tx err: %s",
-				batchSize, err.Error())
-			return err
-		}
-
-		rowsAffected = tRows
-		return nil
-	})
-
-	return uint32(rowsAffected), err
+// batchCleanDeleted 清理一批软删除超过 timeout 的数据，子表中关联的数据一起删除
+func (m *adminStore) batchCleanDeleted(table string, timeout time.Duration, batchSize uint32) (uint32, error) {
+	target, ok := getPurgeTarget(table)
+	if !ok {
+		return 0, fmt.Errorf("purge target %s is not registered", table)
+	}
+	rows, err := m.master.purgeBatch(context.Background(), target, timeout, int(batchSize))
+	if err != nil {
+		log.Errorf("[Store][database] batch clean soft deleted %s(%d), err: %s", table, batchSize, err.Error())
+		return 0, store.Error(err)
+	}
+	return uint32(rows), nil
 }
 
 // GetUnHealthyInstances 获取实例
@@ -578,7 +577,9 @@ func (m *adminStore) GetUnHealthyInstances(timeout time.Duration, limit uint32)
 	`
 
 	// 执行 PostgreSQL 查询
-	rows, err := m.master.Query(queryStr, int32(timeout.Seconds()), limit)
+	ctx, cancel := newOpContext(context.Background(), opAdminCleanup)
+	defer cancel()
+	rows, err := m.master.QueryContext(ctx, "adminStore.GetUnHealthyInstances", queryStr, int32(timeout.Seconds()), limit)
 	if err != nil {
 		log.Errorf("[Store][database] get unhealthy instances, err: %s", err.Error())
 		return nil, store.Error(err)
@@ -606,43 +607,5 @@ func (m *adminStore) GetUnHealthyInstances(timeout time.Duration, limit uint32)
 // BatchCleanDeletedClients 批量删除客户端
 func (m *adminStore) BatchCleanDeletedClients(timeout time.Duration, batchSize uint32) (uint32, error) {
 	log.Infof("[Store][database] batch clean soft deleted clients(%d)", batchSize)
-	var rows int64
-	err := m.master.processWithTransaction("batchCleanDeletedClients", func(tx *BaseTx) error {
-		// PostgreSQL 语句，使用 EXTRACT 和 TO_TIMESTAMP 来替代 FROM_UNIXTIME 和 UNIX_TIMESTAMP
-		mainStr := `
-		WITH deleted_clients AS (
-			SELECT id 
-			FROM client 
-			WHERE flag = 1 
-			AND mtime <= TO_TIMESTAMP(EXTRACT(EPOCH FROM NOW()) - $1) 
-			LIMIT $2
-		)
-		DELETE FROM client WHERE id IN (SELECT id FROM deleted_clients);
-		`
-		// 执行 PostgreSQL 删除语句
-		result, err := tx.Exec(mainStr, int32(timeout.Seconds()), batchSize)
-		if err != nil {
-			log.Errorf("[Store][database] batch clean soft deleted clients(%d), err: %s", batchSize, err.Error())
-			return store.Error(err)
-		}
-
-		// 获取受影响的行数
-		tRows, err := result.RowsAffected()
-		if err != nil {
-			log.Warnf("[Store][database] batch clean soft deleted clients(%d), get RowsAffected err: %s",
-				batchSize, err.Error())
-			return store.Error(err)
-		}
-
-		// 提交事务
-		if err := tx.Commit(); err != nil {
-			log.Errorf("[Store][database] batch clean soft deleted clients(%d) commit tx err: %s",
-				batchSize, err.Error())
-			return err
-		}
-
-		rows = tRows
-		return nil
-	})
-	return uint32(rows), err
+	return m.batchCleanDeleted("client", timeout, batchSize)
 }
@@ -21,6 +21,9 @@ import (
 	"fmt"
 	"testing"
 	"time"
+
+	"github.com/polarismesh/polaris/common/utils"
+	. "github.com/smartystreets/goconvey/convey"
 )
 
 const (
@@ -62,6 +65,70 @@ func TestReleaseLeaderElection(t *testing.T) {
 	fmt.Printf("resp,err: %+v\n", err)
 }
 
+// TestLeaderLeaseClockSkew 租期只依赖数据库时钟，本地时钟与数据库时钟存在偏差时判断结果不变
+func TestLeaderLeaseClockSkew(t *testing.T) {
+	obj := initConf()
+	if obj.master == nil {
+		return
+	}
+	key := "test-lease-skew"
+	le := &leaderElectionStore{master: obj.master}
+	_ = le.CreateLeaderElection(key)
+	setMtime := func(expr string, args ...interface{}) {
+		_, err := obj.master.Exec("TestLeaderLeaseClockSkew", "UPDATE leader_election SET leader = 'skew', mtime = "+expr+
+			" WHERE elect_key = $1", append([]interface{}{key}, args...)...)
+		So(err, ShouldBeNil)
+	}
+	valid := func() bool {
+		list, err := le.ListLeaderElections()
+		So(err, ShouldBeNil)
+		for _, item := range list {
+			if item.ElectKey == key {
+				return item.Valid
+			}
+		}
+		return false
+	}
+
+	Convey("按数据库时钟判断租期", t, func() {
+		setMtime(fmt.Sprintf("now() - interval '%d seconds'", LeaseTime-5))
+		_, expired, err := le.CheckMtimeExpired(key, LeaseTime)
+		So(err, ShouldBeNil)
+		So(expired, ShouldBeFalse)
+		So(valid(), ShouldBeTrue)
+
+		setMtime(fmt.Sprintf("now() - interval '%d seconds'", LeaseTime+5))
+		_, expired, err = le.CheckMtimeExpired(key, LeaseTime)
+		So(err, ShouldBeNil)
+		So(expired, ShouldBeTrue)
+		So(valid(), ShouldBeFalse)
+	})
+	Convey("本地时钟落后一小时写入的 mtime 视为过期", t, func() {
+		setMtime("$2", time.Now().Add(-time.Hour))
+		_, expired, err := le.CheckMtimeExpired(key, LeaseTime)
+		So(err, ShouldBeNil)
+		So(expired, ShouldBeTrue)
+	})
+	Convey("本地时钟超前时心跳仍然按数据库时钟续约", t, func() {
+		setMtime("$2", time.Now().Add(time.Hour))
+		version, err := le.GetVersion(key)
+		So(err, ShouldBeNil)
+		ok, err := le.CompareAndSwapVersion(key, version, version+1, utils.LocalHost)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+
+		var drift float64
+		err = obj.master.QueryRow("TestLeaderLeaseClockSkew",
+			"SELECT abs(EXTRACT(EPOCH FROM now() - mtime)) FROM leader_election WHERE elect_key = $1", key).Scan(&drift)
+		So(err, ShouldBeNil)
+		So(drift, ShouldBeLessThan, TickTime)
+		leader, expired, err := le.CheckMtimeExpired(key, LeaseTime)
+		So(err, ShouldBeNil)
+		So(expired, ShouldBeFalse)
+		So(leader, ShouldEqual, utils.LocalHost)
+	})
+}
+
 func TestBatchCleanDeletedInstances(t *testing.T) {
 	obj := initConf()
 
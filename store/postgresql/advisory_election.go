@@ -0,0 +1,221 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+
+	"github.com/polarismesh/polaris/common/utils"
+	"github.com/polarismesh/polaris/store"
+)
+
+const (
+	// LeaderElectionTable 基于 leader_election 表的版本号与租期进行选举，默认的选举方式
+	LeaderElectionTable = "table"
+	// LeaderElectionAdvisoryLock 基于会话级 advisory lock 进行选举，持有锁的连接断开后数据库会立即释放锁
+	LeaderElectionAdvisoryLock = "advisoryLock"
+)
+
+// advisoryLockKeySql 将选举键映射为 advisory lock 的 bigint 键，带上 schema 避免多套部署共用一个库时相互抢占
+const advisoryLockKeySql = "hashtextextended(current_schema() || '.' || $1, 0)"
+
+// leaderElectionReleaser 支持主动释放选举的后端，leader 放弃领导权或者选举停止时调用
+type leaderElectionReleaser interface {
+	ReleaseLeaderElection(key string) error
+}
+
+// parseLeaderElectionBackend 解析 leaderElection.backend 配置
+func parseLeaderElectionBackend(opts interface{}) (string, error) {
+	if opts == nil {
+		return LeaderElectionTable, nil
+	}
+	obj, ok := opts.(map[interface{}]interface{})
+	if !ok {
+		return "", fmt.Errorf("config Plugin %s:leaderElection type must be map", STORENAME)
+	}
+	val, ok := obj["backend"]
+	if !ok {
+		return LeaderElectionTable, nil
+	}
+	backend, _ := val.(string)
+	switch backend {
+	case LeaderElectionTable, LeaderElectionAdvisoryLock:
+		return backend, nil
+	}
+	return "", fmt.Errorf("config Plugin %s:leaderElection.backend must be %s or %s",
+		STORENAME, LeaderElectionTable, LeaderElectionAdvisoryLock)
+}
+
+// newLeaderElectionStore 按配置创建选举后端
+func newLeaderElectionStore(master *BaseDB, backend string) LeaderElectionStore {
+	if backend == LeaderElectionAdvisoryLock {
+		return newAdvisoryLockElectionStore(master)
+	}
+	return &leaderElectionStore{master: master}
+}
+
+// advisoryLockElectionStore 基于 pg_try_advisory_lock 的选举后端
+// 每个选举键在成为 leader 后独占一个数据库连接持有会话级锁，leader 进程退出或者网络断开时锁随会话释放，
+// 不需要等待租期过期；leader_election 表仍然记录 leader 与版本号，用于展示选举信息
+type advisoryLockElectionStore struct {
+	*leaderElectionStore
+	mutex sync.Mutex
+	conns map[string]*sql.Conn
+}
+
+func newAdvisoryLockElectionStore(master *BaseDB) *advisoryLockElectionStore {
+	return &advisoryLockElectionStore{
+		leaderElectionStore: &leaderElectionStore{master: master},
+		conns:               make(map[string]*sql.Conn),
+	}
+}
+
+// CompareAndSwapVersion 持有锁时在锁所在的会话里更新版本号，会话失效时更新失败，避免旧 leader 覆盖新 leader 的记录
+func (a *advisoryLockElectionStore) CompareAndSwapVersion(key string, curVersion int64, newVersion int64,
+	leader string) (bool, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	conn, err := a.lockLocked(key)
+	if err != nil || conn == nil {
+		return false, err
+	}
+
+	ctx, cancel := newOpContext(context.Background(), opWrite)
+	defer cancel()
+	result, err := conn.ExecContext(ctx, "UPDATE leader_election SET leader = $1, version = $2, mtime = now() "+
+		"WHERE elect_key = $3 AND version = $4", leader, newVersion, key, curVersion)
+	if err != nil {
+		log.Errorf("[Store][database] advisory lock compare and swap version (%s), err: %s", key, err.Error())
+		a.releaseLocked(key)
+		return false, store.Error(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		a.releaseLocked(key)
+		return false, store.Error(err)
+	}
+	if rows == 0 {
+		// 持有锁但版本号不一致，说明本地状态已经落后，释放锁后重新参与选举
+		log.Warnf("[Store][database] advisory lock version mismatch (%s, %d), release lock", key, curVersion)
+		a.releaseLocked(key)
+		return false, nil
+	}
+	return true, nil
+}
+
+// CheckMtimeExpired 以锁是否被持有判断 leader 是否存活，不依赖 mtime 与租期
+func (a *advisoryLockElectionStore) CheckMtimeExpired(key string, _ int32) (string, bool, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if conn, ok := a.conns[key]; ok {
+		ctx, cancel := newOpContext(context.Background(), opWrite)
+		err := conn.PingContext(ctx)
+		cancel()
+		if err == nil {
+			return utils.LocalHost, false, nil
+		}
+		log.Warnf("[Store][database] advisory lock session lost (%s)", key)
+		a.releaseLocked(key)
+	}
+
+	var leader string
+	err := a.master.QueryRow("advisoryLockElectionStore.CheckMtimeExpired",
+		"SELECT leader FROM leader_election WHERE elect_key = $1", key).Scan(&leader)
+	if err != nil {
+		log.Errorf("[Store][database] advisory lock query leader (%s), err: %s", key, err.Error())
+		return "", false, store.Error(err)
+	}
+
+	conn, err := a.lockLocked(key)
+	if err != nil {
+		return "", false, err
+	}
+	if conn != nil {
+		// 抢到了锁，说明原来的 leader 已经释放，由后续的 CompareAndSwapVersion 写入新的 leader
+		return leader, true, nil
+	}
+	if leader == utils.LocalHost {
+		// 锁被其他会话持有，表中残留的本机记录不能让状态机误判为 leader
+		leader = ""
+	}
+	return leader, false, nil
+}
+
+// ReleaseLeaderElection 释放选举键对应的锁并归还连接
+func (a *advisoryLockElectionStore) ReleaseLeaderElection(key string) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.releaseLocked(key)
+	return nil
+}
+
+// lockLocked 返回持有锁的连接，锁被其他会话持有时返回 nil，调用方需持有 mutex
+func (a *advisoryLockElectionStore) lockLocked(key string) (*sql.Conn, error) {
+	if conn, ok := a.conns[key]; ok {
+		return conn, nil
+	}
+
+	ctx, cancel := newOpContext(context.Background(), opWrite)
+	defer cancel()
+	conn, err := a.master.DB.Conn(ctx)
+	if err != nil {
+		log.Errorf("[Store][database] advisory lock get conn (%s), err: %s", key, err.Error())
+		return nil, store.Error(err)
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock("+advisoryLockKeySql+")",
+		key).Scan(&locked); err != nil {
+		log.Errorf("[Store][database] advisory lock try lock (%s), err: %s", key, err.Error())
+		_ = conn.Close()
+		return nil, store.Error(err)
+	}
+	if !locked {
+		_ = conn.Close()
+		return nil, nil
+	}
+	log.Infof("[Store][database] advisory lock acquired (%s)", key)
+	a.conns[key] = conn
+	return conn, nil
+}
+
+// releaseLocked 解锁并归还连接，解锁失败时直接丢弃连接，会话关闭后锁同样会被释放，调用方需持有 mutex
+func (a *advisoryLockElectionStore) releaseLocked(key string) {
+	conn, ok := a.conns[key]
+	if !ok {
+		return
+	}
+	delete(a.conns, key)
+
+	ctx, cancel := newOpContext(context.Background(), opWrite)
+	defer cancel()
+	var unlocked bool
+	err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock("+advisoryLockKeySql+")", key).Scan(&unlocked)
+	if err != nil || !unlocked {
+		log.Warnf("[Store][database] advisory lock unlock (%s) failed, discard conn", key)
+		_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+	_ = conn.Close()
+	log.Infof("[Store][database] advisory lock released (%s)", key)
+}
@@ -0,0 +1,129 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polarismesh/polaris/common/model"
+	"github.com/polarismesh/polaris/common/utils"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseLeaderElectionBackend(t *testing.T) {
+	Convey("默认使用 leader_election 表", t, func() {
+		backend, err := parseLeaderElectionBackend(nil)
+		So(err, ShouldBeNil)
+		So(backend, ShouldEqual, LeaderElectionTable)
+		backend, err = parseLeaderElectionBackend(map[interface{}]interface{}{})
+		So(err, ShouldBeNil)
+		So(backend, ShouldEqual, LeaderElectionTable)
+	})
+	Convey("选择 advisory lock", t, func() {
+		backend, err := parseLeaderElectionBackend(map[interface{}]interface{}{"backend": "advisoryLock"})
+		So(err, ShouldBeNil)
+		So(backend, ShouldEqual, LeaderElectionAdvisoryLock)
+		_, ok := newLeaderElectionStore(&BaseDB{}, backend).(*advisoryLockElectionStore)
+		So(ok, ShouldBeTrue)
+	})
+	Convey("非法配置", t, func() {
+		_, err := parseLeaderElectionBackend("advisoryLock")
+		So(err, ShouldNotBeNil)
+		_, err = parseLeaderElectionBackend(map[interface{}]interface{}{"backend": "zk"})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+// releaseRecorder 记录状态机释放选举锁的次数
+type releaseRecorder struct {
+	LeaderElectionStore
+	released []string
+}
+
+func (r *releaseRecorder) CompareAndSwapVersion(string, int64, int64, string) (bool, error) {
+	return true, nil
+}
+
+func (r *releaseRecorder) ListLeaderElections() ([]*model.LeaderElection, error) {
+	return nil, nil
+}
+
+func (r *releaseRecorder) ReleaseLeaderElection(key string) error {
+	r.released = append(r.released, key)
+	return nil
+}
+
+func TestLeaderElectionRelease(t *testing.T) {
+	Convey("leader 主动放弃时释放选举锁", t, func() {
+		recorder := &releaseRecorder{}
+		le := &leaderElectionStateMachine{electKey: TestElectKey, leStore: recorder}
+		le.changeToLeader()
+		le.setReleaseSignal()
+		le.tick()
+		So(le.isLeader(), ShouldBeFalse)
+		So(recorder.released, ShouldResemble, []string{TestElectKey})
+	})
+	Convey("停止选举时释放选举锁", t, func() {
+		recorder := &releaseRecorder{}
+		ctx, cancel := context.WithCancel(context.Background())
+		le := &leaderElectionStateMachine{electKey: TestElectKey, leStore: recorder, ctx: ctx, cancel: cancel}
+		cancel()
+		le.mainLoop()
+		So(recorder.released, ShouldResemble, []string{TestElectKey})
+	})
+}
+
+func TestAdvisoryLockElection(t *testing.T) {
+	obj := initConf()
+	if obj.master == nil {
+		return
+	}
+	key := "test-advisory-lock"
+	first := newAdvisoryLockElectionStore(obj.master)
+	second := newAdvisoryLockElectionStore(obj.master)
+	_ = first.CreateLeaderElection(key)
+	defer func() {
+		_ = first.ReleaseLeaderElection(key)
+		_ = second.ReleaseLeaderElection(key)
+	}()
+
+	Convey("同一时刻只有一个会话能成为 leader", t, func() {
+		_, dead, err := first.CheckMtimeExpired(key, LeaseTime)
+		So(err, ShouldBeNil)
+		So(dead, ShouldBeTrue)
+		version, err := first.GetVersion(key)
+		So(err, ShouldBeNil)
+		ok, err := first.CompareAndSwapVersion(key, version, version+1, utils.LocalHost)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+
+		_, dead, err = second.CheckMtimeExpired(key, LeaseTime)
+		So(err, ShouldBeNil)
+		So(dead, ShouldBeFalse)
+		ok, err = second.CompareAndSwapVersion(key, version+1, version+2, "other")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+	})
+	Convey("释放后其他会话可以抢到锁", t, func() {
+		So(first.ReleaseLeaderElection(key), ShouldBeNil)
+		_, dead, err := second.CheckMtimeExpired(key, LeaseTime)
+		So(err, ShouldBeNil)
+		So(dead, ShouldBeTrue)
+	})
+}
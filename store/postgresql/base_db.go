@@ -20,9 +20,9 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"github.com/polarismesh/polaris/common/metrics"
-	"github.com/polarismesh/polaris/store"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,8 +30,25 @@ import (
 	"github.com/polarismesh/polaris/plugin"
 )
 
-// db抛出的异常，需要重试的字符串组
-var errMsg = []string{"Deadlock", "bad connection", "invalid connection"}
+// supportedIsolationLevels PostgreSQL 支持的事务隔离级别
+var supportedIsolationLevels = []sql.IsolationLevel{
+	sql.LevelDefault, sql.LevelReadCommitted, sql.LevelRepeatableRead, sql.LevelSerializable,
+}
+
+// DefaultSSLMode 默认不开启 TLS，与历史行为保持一致
+const DefaultSSLMode = "disable"
+
+// validSSLModes libpq 支持的 sslmode
+var validSSLModes = map[string]struct{}{
+	"disable": {}, "allow": {}, "prefer": {}, "require": {}, "verify-ca": {}, "verify-full": {},
+}
+
+// reservedDSNKeys 由独立配置项生成的连接参数，不允许通过 extraParams 覆盖
+var reservedDSNKeys = map[string]struct{}{
+	"host": {}, "port": {}, "user": {}, "password": {}, "dbname": {}, "sslmode": {}, "sslrootcert": {},
+	"sslcert": {}, "sslkey": {}, "application_name": {}, "connect_timeout": {}, "search_path": {},
+	"timezone": {},
+}
 
 // BaseDB 对sql.DB的封装
 type BaseDB struct {
@@ -39,6 +56,7 @@ type BaseDB struct {
 	cfg            *dbConfig
 	isolationLevel sql.IsolationLevel
 	parsePwd       plugin.ParsePassword
+	stmts          *stmtCache
 }
 
 // dbConfig store的配置
@@ -49,15 +67,26 @@ type dbConfig struct {
 	dbAddr           string
 	dbPort           string
 	dbName           string
+	dbSchema         string
 	maxOpenConns     int
 	maxIdleConns     int
 	connMaxLifetime  int
 	txIsolationLevel int
+	sslMode          string
+	sslRootCert      string
+	sslCert          string
+	sslKey           string
+	applicationName  string
+	connectTimeout   int
+	extraParams      map[string]string
+	timezone         string
+	stmtCacheSize    int
+	weight           int // 只读实例的权重，仅对slave生效
 }
 
 // NewBaseDB 新建一个BaseDB
 func NewBaseDB(cfg *dbConfig, parsePwd plugin.ParsePassword) (*BaseDB, error) {
-	baseDb := &BaseDB{cfg: cfg, parsePwd: parsePwd}
+	baseDb := &BaseDB{cfg: cfg, parsePwd: parsePwd, isolationLevel: sql.IsolationLevel(cfg.txIsolationLevel)}
 	if err := baseDb.openDatabase(); err != nil {
 		return nil, err
 	}
@@ -78,9 +107,7 @@ func (b *BaseDB) openDatabase() error {
 		c.dbPwd = pwd
 	}
 
-	dns := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s "+
-		"sslmode=disable", c.dbAddr, c.dbPort, c.dbUser, c.dbPwd, c.dbName)
-	db, err := sql.Open(c.dbType, dns)
+	db, err := openDB(c.dbType, buildDSN(c))
 	if err != nil {
 		log.Errorf("[Store][database] sql open err: %s", err.Error())
 		return err
@@ -103,19 +130,103 @@ func (b *BaseDB) openDatabase() error {
 	}
 
 	b.DB = db
+	b.stmts = newStmtCache(db, c.stmtCacheSize)
 
 	return nil
 }
 
-// Exec 重写db.Exec函数 提供重试功能
-func (b *BaseDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+// Prepare 返回缓存的预编译语句，语句由缓存统一关闭，调用方不需要关闭
+func (b *BaseDB) Prepare(query string) (*sql.Stmt, error) {
+	if b.stmts == nil {
+		return b.DB.Prepare(query)
+	}
+	return b.stmts.get(context.Background(), query)
+}
+
+// Close 关闭缓存的预编译语句及数据库连接
+func (b *BaseDB) Close() error {
+	b.stmts.close()
+	return b.DB.Close()
+}
+
+// buildDSN 生成 libpq 格式的连接串
+func buildDSN(c *dbConfig) string {
+	sslMode := c.sslMode
+	if sslMode == "" {
+		sslMode = DefaultSSLMode
+	}
+	params := []string{
+		"host=" + quoteDSNValue(c.dbAddr),
+		"port=" + quoteDSNValue(c.dbPort),
+		"user=" + quoteDSNValue(c.dbUser),
+		"password=" + quoteDSNValue(c.dbPwd),
+		"dbname=" + quoteDSNValue(c.dbName),
+		"sslmode=" + quoteDSNValue(sslMode),
+	}
+	if c.sslRootCert != "" {
+		params = append(params, "sslrootcert="+quoteDSNValue(c.sslRootCert))
+	}
+	if c.sslCert != "" {
+		params = append(params, "sslcert="+quoteDSNValue(c.sslCert), "sslkey="+quoteDSNValue(c.sslKey))
+	}
+	if c.applicationName != "" {
+		params = append(params, "application_name="+quoteDSNValue(c.applicationName))
+	}
+	if c.connectTimeout > 0 {
+		params = append(params, fmt.Sprintf("connect_timeout=%d", c.connectTimeout))
+	}
+	// lib/pq 与 pgx 均会将 search_path 作为运行时参数在建立连接时发送
+	if c.dbSchema != "" {
+		params = append(params, "search_path="+quoteDSNValue(quoteIdentifier(c.dbSchema)))
+	}
+	// 每个连接的会话时区与 store 的时区一致，不带时区偏移的时间字符串按该时区解释
+	if c.timezone != "" {
+		params = append(params, "timezone="+quoteDSNValue(c.timezone))
+	}
+	keys := make([]string, 0, len(c.extraParams))
+	for key := range c.extraParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		params = append(params, key+"="+quoteDSNValue(c.extraParams[key]))
+	}
+	return strings.Join(params, " ")
+}
+
+// quoteDSNValue 按照 libpq 的规则对参数值进行转义
+func quoteDSNValue(val string) string {
+	if val != "" && !strings.ContainsAny(val, " '\\\t") {
+		return val
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(val) + "'"
+}
+
+// quoteIdentifier 将名称转义为 SQL 中带引号的标识符
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Exec 重写db.Exec函数 提供重试功能，label 为发起调用的 store 方法，如 instanceStore.GetMoreInstances
+func (b *BaseDB) Exec(label string, query string, args ...interface{}) (sql.Result, error) {
+	return b.ExecContext(context.Background(), label, query, args...)
+}
+
+// ExecContext 重写db.ExecContext函数 提供重试功能，连接异常时语句可能已经执行，只在语句确定未生效时重试
+func (b *BaseDB) ExecContext(ctx context.Context, label string, query string,
+	args ...interface{}) (sql.Result, error) {
 	var (
 		result sql.Result
 		err    error
+		start  = time.Now()
 	)
+	defer func() {
+		reportCallMetrics(label, "Exec", start, err)
+	}()
 
-	Retry("exec "+query, func() error {
-		result, err = b.DB.Exec(query, args...)
+	ctx = withCallLabel(ctx, label)
+	_ = retryWithPolicy("exec "+query, isExecRetryable, func() error {
+		result, err = b.DB.ExecContext(ctx, query, args...)
 		return err
 	})
 
@@ -123,31 +234,57 @@ func (b *BaseDB) Exec(query string, args ...interface{}) (sql.Result, error) {
 }
 
 // Query 重写db.Query函数
-func (b *BaseDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+func (b *BaseDB) Query(label string, query string, args ...interface{}) (*sql.Rows, error) {
+	return b.QueryContext(context.Background(), label, query, args...)
+}
+
+// QueryContext 重写db.QueryContext函数，ctx 需要在 rows 读取完毕后才能取消
+func (b *BaseDB) QueryContext(ctx context.Context, label string, query string,
+	args ...interface{}) (*sql.Rows, error) {
 	var (
-		rows *sql.Rows
-		err  error
+		rows  *sql.Rows
+		err   error
+		start = time.Now()
 	)
+	defer func() {
+		reportCallMetrics(label, "Query", start, err)
+	}()
 
+	ctx = withCallLabel(ctx, label)
 	Retry("query "+query, func() error {
-		rows, err = b.DB.Query(query, args...)
+		rows, err = b.DB.QueryContext(ctx, query, args...)
 		return err
 	})
 
 	return rows, err
 }
 
+// queryHandler 以 label 执行查询的 QueryHandler
+func (b *BaseDB) queryHandler(label string) QueryHandler {
+	return func(query string, args ...interface{}) (*sql.Rows, error) {
+		return b.Query(label, query, args...)
+	}
+}
+
 // QueryRow 重写db.Query函数
-func (b *BaseDB) QueryRow(query string, args ...interface{}) *sql.Row {
+func (b *BaseDB) QueryRow(label string, query string, args ...interface{}) *sql.Row {
+	return b.QueryRowContext(context.Background(), label, query, args...)
+}
+
+// QueryRowContext 重写db.QueryRowContext函数，ctx 需要在 Scan 之后才能取消
+func (b *BaseDB) QueryRowContext(ctx context.Context, label string, query string, args ...interface{}) *sql.Row {
 	var (
 		row   *sql.Row
 		err   error
 		start = time.Now()
 	)
-	defer reportCallMetrics("QueryRow", start, err)
+	defer func() {
+		reportCallMetrics(label, "QueryRow", start, err)
+	}()
 
+	ctx = withCallLabel(ctx, label)
 	Retry("query "+query, func() error {
-		row = b.DB.QueryRow(query, args...)
+		row = b.DB.QueryRowContext(ctx, query, args...)
 		err = row.Err()
 		return row.Err()
 	})
@@ -155,44 +292,186 @@ func (b *BaseDB) QueryRow(query string, args ...interface{}) *sql.Row {
 	return row
 }
 
-// Begin 重写db.Begin
-func (b *BaseDB) Begin() (*BaseTx, error) {
+// Begin 重写db.Begin，按照配置的事务隔离级别开启事务，使用写操作的默认超时时间
+// 事务内的语句均按 label 上报指标
+func (b *BaseDB) Begin(label string) (*BaseTx, error) {
+	return b.beginWithClass(context.Background(), opWrite, label)
+}
+
+// beginWithClass 开启事务，操作类型的默认超时时间作用于事务内的每条语句而不是整个事务
+// database/sql 会在开启事务的 ctx 结束时回滚事务，因此事务本身不设置截止时间，只在 Commit/Rollback 时释放
+func (b *BaseDB) beginWithClass(parent context.Context, class opClass, label string) (*BaseTx, error) {
+	ctx, cancel := context.WithCancel(parent)
+	tx, err := b.begin(ctx, label, opTimeouts[class])
+	if err != nil {
+		cancel()
+		return tx, err
+	}
+	tx.cancel = cancel
+	return tx, nil
+}
+
+// BeginContext 使用 ctx 开启事务，事务内的语句均使用该 ctx 执行
+// ctx 有截止时间时，同时通过 SET LOCAL statement_timeout 在数据库侧限制语句的执行时间
+func (b *BaseDB) BeginContext(ctx context.Context, label string) (*BaseTx, error) {
+	return b.begin(ctx, label, 0)
+}
+
+// begin 开启事务，timeout 大于0时作为每条语句的超时时间，否则按 ctx 的剩余时间限制语句的执行时间
+func (b *BaseDB) begin(ctx context.Context, label string, timeout time.Duration) (*BaseTx, error) {
 	var tx *sql.Tx
 	var err error
 	var option *sql.TxOptions
 	var start = time.Now()
 
-	defer reportCallMetrics("Begin", start, err)
+	if b.isolationLevel != sql.LevelDefault {
+		option = &sql.TxOptions{Isolation: b.isolationLevel}
+	}
+
+	defer func() {
+		reportCallMetrics(label, "Begin", start, err)
+	}()
 
+	ctx = withCallLabel(ctx, label)
 	Retry("begin", func() error {
-		tx, err = b.DB.BeginTx(context.Background(), option)
+		tx, err = b.DB.BeginTx(ctx, option)
+		if err != nil {
+			return err
+		}
+		timeoutSql := statementTimeoutSql(ctx)
+		if timeout > 0 {
+			timeoutSql = fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())
+		}
+		if timeoutSql != "" {
+			if _, err = tx.ExecContext(ctx, timeoutSql); err != nil {
+				_ = tx.Rollback()
+			}
+		}
 		return err
 	})
 
-	return &BaseTx{Tx: tx}, err
-}
-
-func reportCallMetrics(label string, start time.Time, err error) {
-	plugin.GetStatis().ReportCallMetrics(metrics.CallMetric{
-		Type:     metrics.StoreCallMetric,
-		API:      label,
-		Protocol: "MySQL",
-		Code: func() int {
-			if err == nil {
-				return 0
-			}
-			return int(store.Code(store.Error(err)))
-		}(),
-		Times:            1,
-		Success:          err == nil,
-		Duration:         time.Since(start),
-		TrafficDirection: metrics.TrafficDirectionOutBound,
-	})
+	return &BaseTx{Tx: tx, ctx: ctx, label: label, timeout: timeout, stmts: b.stmts}, err
 }
 
 // BaseTx 对sql.Tx的封装
 type BaseTx struct {
 	*sql.Tx
+	// ctx 开启事务时使用的 context，事务内的语句均使用该 ctx 执行
+	ctx    context.Context
+	cancel context.CancelFunc
+	// label 开启事务的 store 方法，事务内的语句均按该方法上报指标
+	label string
+	// timeout 每条语句的超时时间，为0时语句直接使用事务的 ctx
+	timeout time.Duration
+	// stmts 所属数据库的预编译语句缓存
+	stmts *stmtCache
+	// misses 事务内未命中缓存的语句，事务结束后加入缓存
+	misses []string
+}
+
+// context 事务的 context
+func (b *BaseTx) context() context.Context {
+	if b.ctx == nil {
+		return context.Background()
+	}
+	return b.ctx
+}
+
+// callContext 单条语句使用的 context，在事务的 context 上附加语句的超时时间
+// 事务结束时事务的 context 被取消，未主动释放的语句 context 随之释放
+func (b *BaseTx) callContext() (context.Context, context.CancelFunc) {
+	if b.timeout <= 0 {
+		return b.context(), func() {}
+	}
+	return context.WithTimeout(b.context(), b.timeout)
+}
+
+// createReadView 将事务切换为可重复读，事务内的所有查询读取同一个快照，需要在事务的第一条查询之前调用
+func (b *BaseTx) createReadView() error {
+	_, err := b.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ")
+	return err
+}
+
+// Exec 使用事务的 context 执行语句
+func (b *BaseTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	ctx, cancel := b.callContext()
+	defer cancel()
+	result, err := b.Tx.ExecContext(ctx, query, args...)
+	reportCallMetrics(b.label, "Exec", start, err)
+	return result, err
+}
+
+// Query 使用事务的 context 执行查询，rows 需要在语句的超时时间内读取完毕
+func (b *BaseTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	ctx, cancel := b.callContext()
+	rows, err := b.Tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+	}
+	reportCallMetrics(b.label, "Query", start, err)
+	return rows, err
+}
+
+// QueryRow 使用事务的 context 执行单行查询
+func (b *BaseTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	ctx, cancel := b.callContext()
+	row := b.Tx.QueryRowContext(ctx, query, args...)
+	if row.Err() != nil {
+		cancel()
+	}
+	reportCallMetrics(b.label, "QueryRow", start, row.Err())
+	return row
+}
+
+// Prepare 通过 sql.Tx.Stmt 复用缓存的预编译语句，事务结束时返回的语句自动关闭
+// 缓存未命中时直接在事务连接上预编译，事务结束、连接归还后再预编译到缓存中供后续事务复用
+func (b *BaseTx) Prepare(query string) (*sql.Stmt, error) {
+	var (
+		stmt  *sql.Stmt
+		err   error
+		start = time.Now()
+	)
+	ctx, cancel := b.callContext()
+	defer cancel()
+	if b.stmts != nil {
+		stmt = b.stmts.lookup(query)
+	}
+	if stmt != nil {
+		stmt = b.Tx.StmtContext(ctx, stmt)
+	} else if stmt, err = b.Tx.PrepareContext(ctx, query); err == nil && b.stmts != nil {
+		b.misses = append(b.misses, query)
+	}
+	reportCallMetrics(b.label, "Prepare", start, err)
+	return stmt, err
+}
+
+// prepareDynamic 在事务上预编译动态生成的语句，如不定长的 IN 列表、批量写入的 VALUES 等
+// 这类语句的文本随参数个数变化，不进入缓存，避免挤占常用语句
+func (b *BaseTx) prepareDynamic(query string) (*sql.Stmt, error) {
+	start := time.Now()
+	ctx, cancel := b.callContext()
+	defer cancel()
+	stmt, err := b.Tx.PrepareContext(ctx, query)
+	reportCallMetrics(b.label, "Prepare", start, err)
+	return stmt, err
+}
+
+// release 事务结束后释放 context，并将事务内未命中缓存的语句预编译到缓存中
+func (b *BaseTx) release() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+	misses := b.misses
+	b.misses = nil
+	for _, query := range misses {
+		if b.stmts.saturated() {
+			return
+		}
+		_, _ = b.stmts.get(context.Background(), query)
+	}
 }
 
 // Commit .
@@ -201,66 +480,47 @@ func (b *BaseTx) Commit() error {
 		start = time.Now()
 		err   error
 	)
-	defer reportCallMetrics("Commit", start, err)
+	defer func() {
+		reportCallMetrics(b.label, "Commit", start, err)
+	}()
+	defer b.release()
 	err = b.Tx.Commit()
 	return err
 }
 
-// Rollback .
+// Rollback 回滚事务，事务已经提交或回滚时返回 sql.ErrTxDone，不会访问数据库，因此不上报指标
+// processWithTransaction 等在 defer 中回滚的调用方在事务成功提交后均会走到该分支
 func (b *BaseTx) Rollback() error {
 	var (
 		start = time.Now()
 		err   error
 	)
-	defer reportCallMetrics("Rollback", start, err)
+	defer func() {
+		if !errors.Is(err, sql.ErrTxDone) {
+			reportCallMetrics(b.label, "Rollback", start, err)
+		}
+	}()
+	defer b.release()
 	err = b.Tx.Rollback()
 	return err
 }
 
-// Retry 重试主函数
-// 最多重试20次，每次等待5ms*重试次数
-func Retry(label string, handle func() error) {
-	var (
-		err         error
-		maxTryTimes = 20
-	)
-
-	for i := 1; i <= maxTryTimes; i++ {
-		err = handle()
-		if err == nil {
-			return
-		}
-
-		// 是否重试
-		repeated := false
-		for _, msg := range errMsg {
-			if strings.Contains(err.Error(), msg) {
-				log.Warnf("[Store][database][%s] get error msg: %s. Repeated doing(%d)", label, err.Error(), i)
-				time.Sleep(time.Millisecond * 5 * time.Duration(i))
-				repeated = true
-				break
-			}
-		}
-		if !repeated {
-			return
-		}
-	}
+// processWithTransaction 在事务中执行 handle，遇到序列化失败或者死锁时重新开启事务并重试
+func (b *BaseDB) processWithTransaction(label string, handle func(tx *BaseTx) error) error {
+	return b.processWithTransactionContext(context.Background(), opWrite, label, handle)
 }
 
-// RetryTransaction 事务重试
-func RetryTransaction(label string, handle func() error) error {
-	var err error
-
-	Retry(label, func() error {
-		err = handle()
-		return err
+// processWithTransactionContext 按照操作类型的默认超时时间在事务中执行 handle
+func (b *BaseDB) processWithTransactionContext(ctx context.Context, class opClass, label string,
+	handle func(tx *BaseTx) error) error {
+	return retryWithPolicy(label, isTxConflict, func() error {
+		return b.processOnceWithTransaction(ctx, class, label, handle)
 	})
-
-	return err
 }
 
-func (b *BaseDB) processWithTransaction(label string, handle func(tx *BaseTx) error) error {
-	tx, err := b.Begin()
+func (b *BaseDB) processOnceWithTransaction(ctx context.Context, class opClass, label string,
+	handle func(tx *BaseTx) error) error {
+	tx, err := b.beginWithClass(ctx, class, label)
 	if err != nil {
 		log.Errorf("[Store][database] %s begin tx err: %s", label, err.Error())
 		return err
@@ -272,3 +532,34 @@ func (b *BaseDB) processWithTransaction(label string, handle func(tx *BaseTx) er
 
 	return handle(tx)
 }
+
+// parseIsolationLevel 解析事务隔离级别配置，支持 sql.IsolationLevel 的取值或者隔离级别名称
+func parseIsolationLevel(val interface{}) (sql.IsolationLevel, error) {
+	var level sql.IsolationLevel
+	switch v := val.(type) {
+	case nil:
+		return sql.LevelDefault, nil
+	case int:
+		level = sql.IsolationLevel(v)
+	case string:
+		name := strings.ToLower(strings.NewReplacer("_", " ", "-", " ").Replace(strings.TrimSpace(v)))
+		found := false
+		for _, item := range supportedIsolationLevels {
+			if strings.ToLower(item.String()) == name || (name == "" && item == sql.LevelDefault) {
+				level, found = item, true
+				break
+			}
+		}
+		if !found {
+			return sql.LevelDefault, fmt.Errorf("isolation level %s is not supported", v)
+		}
+	default:
+		return sql.LevelDefault, fmt.Errorf("isolation level type must be int or string")
+	}
+	for _, item := range supportedIsolationLevels {
+		if item == level {
+			return level, nil
+		}
+	}
+	return sql.LevelDefault, fmt.Errorf("isolation level %d is not supported", level)
+}
@@ -18,11 +18,14 @@
 package postgresql
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -43,8 +46,8 @@ func TestRetry(t *testing.T) {
 		count := 0
 		Retry("retry", func() error {
 			count++
-			if count <= 10 {
-				err = errors.New("invalid connection")
+			if count <= 5 {
+				err = &pq.Error{Code: "08006", Message: "connection failure"}
 				return err
 			}
 			err = nil
@@ -52,6 +55,7 @@ func TestRetry(t *testing.T) {
 		})
 		sub := time.Since(start)
 		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 6)
 		So(sub, ShouldBeGreaterThan, time.Millisecond)
 	})
 	Convey("只捕获固定的错误", t, func() {
@@ -68,6 +72,78 @@ func TestRetry(t *testing.T) {
 	})
 }
 
+func TestBuildDSN(t *testing.T) {
+	Convey("生成libpq连接串", t, func() {
+		c := &dbConfig{
+			dbUser: "polaris", dbPwd: "it's a secret", dbAddr: "127.0.0.1", dbPort: "5432", dbName: "polaris_server",
+			sslMode: "verify-full", sslRootCert: "/etc/ssl/root.crt", sslCert: "/etc/ssl/client.crt",
+			sslKey: "/etc/ssl/client.key", applicationName: "polaris", connectTimeout: 3,
+			extraParams: map[string]string{"target_session_attrs": "read-write", "keepalives": "1"},
+		}
+		So(buildDSN(c), ShouldEqual, "host=127.0.0.1 port=5432 user=polaris password='it\\'s a secret' "+
+			"dbname=polaris_server sslmode=verify-full sslrootcert=/etc/ssl/root.crt sslcert=/etc/ssl/client.crt "+
+			"sslkey=/etc/ssl/client.key application_name=polaris connect_timeout=3 keepalives=1 "+
+			"target_session_attrs=read-write")
+	})
+	Convey("未配置sslMode时保持disable", t, func() {
+		c := &dbConfig{dbUser: "u", dbPwd: "", dbAddr: "h", dbPort: "1", dbName: "d"}
+		So(buildDSN(c), ShouldEqual, "host=h port=1 user=u password='' dbname=d sslmode=disable")
+	})
+	Convey("配置dbSchema时设置search_path", t, func() {
+		c := &dbConfig{dbUser: "u", dbPwd: "p", dbAddr: "h", dbPort: "1", dbName: "d", dbSchema: `polaris"prod`}
+		So(buildDSN(c), ShouldEqual, `host=h port=1 user=u password=p dbname=d sslmode=disable `+
+			`search_path="polaris""prod"`)
+		c.dbSchema = "polaris prod"
+		So(buildDSN(c), ShouldEqual, `host=h port=1 user=u password=p dbname=d sslmode=disable `+
+			`search_path='"polaris prod"'`)
+	})
+	Convey("配置timezone时设置会话时区", t, func() {
+		c := &dbConfig{dbUser: "u", dbPwd: "p", dbAddr: "h", dbPort: "1", dbName: "d", timezone: "Asia/Shanghai"}
+		So(buildDSN(c), ShouldEqual, `host=h port=1 user=u password=p dbname=d sslmode=disable timezone=Asia/Shanghai`)
+		cfg, err := pgconn.ParseConfig(buildDSN(c))
+		So(err, ShouldBeNil)
+		So(cfg.RuntimeParams["timezone"], ShouldEqual, "Asia/Shanghai")
+	})
+	Convey("pgx可以解析生成的连接串", t, func() {
+		c := &dbConfig{
+			dbUser: "polaris", dbPwd: "it's a secret", dbAddr: "127.0.0.1", dbPort: "5432", dbName: "polaris_server",
+			applicationName: "polaris", connectTimeout: 3, dbSchema: "polaris",
+			extraParams: map[string]string{"target_session_attrs": "read-write"},
+		}
+		cfg, err := pgconn.ParseConfig(buildDSN(c))
+		So(err, ShouldBeNil)
+		So(cfg.RuntimeParams["search_path"], ShouldEqual, `"polaris"`)
+		So(cfg.Password, ShouldEqual, "it's a secret")
+		So(cfg.ConnectTimeout, ShouldEqual, 3*time.Second)
+		So(cfg.RuntimeParams["application_name"], ShouldEqual, "polaris")
+	})
+}
+
+func TestParseIsolationLevel(t *testing.T) {
+	Convey("解析事务隔离级别", t, func() {
+		cases := map[interface{}]sql.IsolationLevel{
+			nil:               sql.LevelDefault,
+			2:                 sql.LevelReadCommitted,
+			6:                 sql.LevelSerializable,
+			"":                sql.LevelDefault,
+			"read committed":  sql.LevelReadCommitted,
+			"REPEATABLE_READ": sql.LevelRepeatableRead,
+			"serializable":    sql.LevelSerializable,
+		}
+		for val, expect := range cases {
+			level, err := parseIsolationLevel(val)
+			So(err, ShouldBeNil)
+			So(level, ShouldEqual, expect)
+		}
+	})
+	Convey("不支持的事务隔离级别", t, func() {
+		for _, val := range []interface{}{5, "snapshot", 1.5} {
+			_, err := parseIsolationLevel(val)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
 // TestRetryTransaction 测试retryTransaction
 func TestRetryTransaction(t *testing.T) {
 	Convey("handle错误可以正常捕获", t, func() {
@@ -79,10 +155,10 @@ func TestRetryTransaction(t *testing.T) {
 
 		start := time.Now()
 		err = RetryTransaction("test-handle", func() error {
-			return errors.New("Deadlock")
+			return &pq.Error{Code: "40P01", Message: "deadlock detected"}
 		})
 		So(err, ShouldNotBeNil)
-		So(err.Error(), ShouldEqual, "Deadlock")
+		So(err.Error(), ShouldEqual, "pq: deadlock detected")
 		sub := time.Since(start)
 		t.Logf("%v", sub)
 		So(sub, ShouldBeGreaterThan, time.Millisecond*100)
@@ -0,0 +1,95 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+)
+
+const (
+	// adminUserID 默认管理员 polaris 的用户ID
+	adminUserID = "65e4789a6d5b49669adf1e9e8387549c"
+	// adminStrategyID 默认管理员的默认鉴权策略ID
+	adminStrategyID = "fbca9bfa04ae4ead86e1ecf5811e32a9"
+)
+
+// bootstrapSqls 与 polaris_server_v1.17.2.sql 中一致的默认数据，主键冲突时不做任何修改
+var bootstrapSqls = []string{
+	"INSERT INTO namespace (name, comment, token, owner, flag) VALUES " +
+		"('Polaris', 'Polaris-server', '2d1bfe5d12e04d54b8ee69e62494c7fd', 'polaris', 0), " +
+		"('default', 'Default Environment', 'e2e473081d3d4306b52264e49f7ce227', 'polaris', 0) " +
+		"ON CONFLICT DO NOTHING",
+	// LockBootstrap 在 start_lock 中随机选择一行加锁，没有数据时无法启动
+	"INSERT INTO start_lock (lock_id, lock_key, server) VALUES (1, 'sz', 'aaa') ON CONFLICT DO NOTHING",
+	"INSERT INTO cl5_module (module_id, interface_id, range_num) VALUES (3000001, 1, 0) ON CONFLICT DO NOTHING",
+}
+
+// bootstrapAdminSqls 默认管理员及其默认策略，仅在 user 表为空时写入，避免重新创建已被删除的管理员
+var bootstrapAdminSqls = []string{
+	"INSERT INTO \"user\" (id, name, password, source, token, token_enable, user_type, comment, " +
+		"mobile, email, owner) VALUES ('" + adminUserID + "', 'polaris', " +
+		"'$2a$10$3izWuZtE5SBdAtSZci.gs.iZ2pAn9I8hEqYrC6gwJp1dyjqQnrrum', 'Polaris', " +
+		"'nu/0WRA4EqSR1FagrjRj0fZwPXuGlMpX+zCuWu4uMqy8xr1vRjisSbA25aAC3mtU8MeeRsKhQiDAynUR09I=', " +
+		"1, 20, 'default polaris admin account', '12345678910', '12345678910', '') ON CONFLICT DO NOTHING",
+	"INSERT INTO auth_strategy (id, name, action, owner, comment, default_status, source, revision, flag) " +
+		"VALUES ('" + adminStrategyID + "', '(用户) polaris的默认策略', 'READ_WRITE', '" + adminUserID + "', " +
+		"'default admin', 1, 'Polaris', '" + adminStrategyID + "', 0) ON CONFLICT DO NOTHING",
+	"INSERT INTO auth_principal (strategy_id, principal_id, principal_role) " +
+		"VALUES ('" + adminStrategyID + "', '" + adminUserID + "', 1) ON CONFLICT DO NOTHING",
+	"INSERT INTO auth_strategy_resource (strategy_id, res_type, res_id) VALUES " +
+		"('" + adminStrategyID + "', 0, '*'), ('" + adminStrategyID + "', 1, '*'), " +
+		"('" + adminStrategyID + "', 2, '*') ON CONFLICT DO NOTHING",
+}
+
+// legacyDefaultColumnSql 由 v1.17.2 升级的数据库仍保留 auth_strategy.default 列，需要与 default_status 保持一致
+const legacyDefaultColumnSql = "SELECT COUNT(*) FROM information_schema.columns " +
+	"WHERE table_schema = current_schema() AND table_name = 'auth_strategy' AND column_name = 'default'"
+
+// bootstrap 在同一个事务中写入默认数据，可重复执行，多个节点同时执行时由主键冲突保证只写入一次
+func (b *BaseDB) bootstrap(ctx context.Context) error {
+	return b.processWithTransactionContext(ctx, opWrite, "bootstrap", func(tx *BaseTx) error {
+		for _, query := range bootstrapSqls {
+			if _, err := tx.Exec(query); err != nil {
+				return err
+			}
+		}
+
+		var users int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM \"user\"").Scan(&users); err != nil {
+			return err
+		}
+		if users == 0 {
+			log.Infof("[Store][database] bootstrap default admin user polaris")
+			for _, query := range bootstrapAdminSqls {
+				if _, err := tx.Exec(query); err != nil {
+					return err
+				}
+			}
+			var legacy int
+			if err := tx.QueryRow(legacyDefaultColumnSql).Scan(&legacy); err != nil {
+				return err
+			}
+			if legacy > 0 {
+				if _, err := tx.Exec("UPDATE auth_strategy SET \"default\" = default_status WHERE id = $1",
+					adminStrategyID); err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Commit()
+	})
+}
@@ -0,0 +1,48 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBootstrapSqls(t *testing.T) {
+	Convey("默认数据的写入语句均可重复执行", t, func() {
+		for _, query := range append(append([]string{}, bootstrapSqls...), bootstrapAdminSqls...) {
+			So(strings.HasPrefix(query, "INSERT INTO "), ShouldBeTrue)
+			So(strings.HasSuffix(query, " ON CONFLICT DO NOTHING"), ShouldBeTrue)
+		}
+	})
+}
+
+func TestBootstrap(t *testing.T) {
+	obj := initConf()
+	if obj.master == nil {
+		return
+	}
+	for i := 0; i < 2; i++ {
+		err := obj.master.bootstrap(context.Background())
+		fmt.Printf("bootstrap: %+v\n", err)
+	}
+	ns, err := obj.namespaceStore.GetNamespace(SystemNamespace)
+	fmt.Printf("namespace: %+v, %+v\n", ns, err)
+}
@@ -0,0 +1,644 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/polarismesh/polaris/common/model"
+	"github.com/polarismesh/polaris/common/utils"
+)
+
+const (
+	// DefaultCDCSlot 默认的逻辑复制槽前缀，未配置 slot 时每个节点使用 前缀_节点地址 作为自己的复制槽
+	DefaultCDCSlot = "polaris_cdc"
+	// DefaultCDCPublication 默认的发布
+	DefaultCDCPublication = "polaris_cdc"
+	// DefaultCDCStatusInterval 上报消费进度的默认间隔
+	DefaultCDCStatusInterval = 10 * time.Second
+	// DefaultCDCMaxLagMB 未被消费的复制槽允许保留的 WAL 上限，默认为0，不删除复制槽
+	DefaultCDCMaxLagMB = 0
+	// cdcTimeLayout timestamp 列在 pgoutput 中的文本格式
+	cdcTimeLayout = "2006-01-02 15:04:05.999999"
+	// objectInUseCode SQLSTATE object_in_use，复制槽正在被其他会话使用
+	objectInUseCode = "55006"
+)
+
+// ErrCDCSlotInUse 复制槽正在被其他消费方使用，同一个复制槽同时只能有一个消费方
+var ErrCDCSlotInUse = errors.New("cdc replication slot is in use by another consumer")
+
+// ErrCDCSlotLost 复制槽被删除后重新创建，已确认的位置之后的变更已经丢失，需要全量加载后重新消费
+var ErrCDCSlotLost = errors.New("cdc replication slot was recreated, changes since the saved offset are lost")
+
+// cdcTimezoneLayouts timestamptz 列在 pgoutput 中的文本格式，时区偏移可能带有分钟
+var cdcTimezoneLayouts = []string{cdcTimeLayout + "-07", cdcTimeLayout + "-07:00"}
+
+// cdcDecoders 需要订阅的表及其对应模型的解码函数
+var cdcDecoders = map[string]func(row cdcRow) interface{}{
+	"service":                decodeCDCService,
+	"instance":               decodeCDCInstance,
+	"config_file_release":    decodeCDCConfigFileRelease,
+	"routing_config_v2":      decodeCDCRouterConfig,
+	"ratelimit_config":       decodeCDCRateLimit,
+	"circuitbreaker_rule_v2": decodeCDCCircuitBreakerRule,
+	"fault_detect_rule":      decodeCDCFaultDetectRule,
+}
+
+// CDCEvent 单行数据变更
+type CDCEvent struct {
+	// Table 发生变更的表
+	Table string
+	// Op INSERT、UPDATE、DELETE 或者 TRUNCATE
+	Op string
+	// Columns 变更后的行，DELETE 时为删除前的行，默认的 replica identity 下只包含主键
+	// NULL 列的值为 nil，未变更的 TOAST 列不包含在内
+	Columns map[string]*string
+	// Object 由 Columns 解码得到的模型，如 *model.Service、*model.Instance，TRUNCATE 时为空
+	Object interface{}
+}
+
+// CDCTransaction 一个已提交事务中订阅表的所有变更
+type CDCTransaction struct {
+	// LSN 事务提交记录结束的位置，处理成功后作为已确认的位置持久化
+	LSN        LSN
+	CommitTime time.Time
+	Events     []*CDCEvent
+}
+
+// CDCHandler 处理一个事务的变更，返回错误时不确认该事务，重连后会再次收到
+type CDCHandler func(tx *CDCTransaction) error
+
+// cdcConfig 逻辑复制配置
+type cdcConfig struct {
+	enable         bool
+	slot           string
+	publication    string
+	statusInterval time.Duration
+	// maxLagMB 未被消费的复制槽允许保留的 WAL 上限，为0时不删除
+	maxLagMB int
+}
+
+// parseCDCConfig 解析逻辑复制配置，默认不开启
+func parseCDCConfig(opts interface{}) (*cdcConfig, error) {
+	cfg := &cdcConfig{slot: nodeCDCSlot(), publication: DefaultCDCPublication,
+		statusInterval: DefaultCDCStatusInterval, maxLagMB: DefaultCDCMaxLagMB}
+	if opts == nil {
+		return cfg, nil
+	}
+	obj, ok := opts.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config Plugin %s:cdc type must be map", STORENAME)
+	}
+	if val, ok := obj["enable"]; ok {
+		if cfg.enable, ok = val.(bool); !ok {
+			return nil, fmt.Errorf("config Plugin %s:cdc.enable type must be bool", STORENAME)
+		}
+	}
+	for key, target := range map[string]*string{"slot": &cfg.slot, "publication": &cfg.publication} {
+		val, ok := obj[key]
+		if !ok {
+			continue
+		}
+		name, _ := val.(string)
+		if !isSimpleIdentifier(name) {
+			return nil, fmt.Errorf("config Plugin %s:cdc.%s must consist of lowercase letters, digits and "+
+				"underscores", STORENAME, key)
+		}
+		*target = name
+	}
+	if val, ok := obj["statusInterval"]; ok {
+		d, err := time.ParseDuration(fmt.Sprintf("%v", val))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("config Plugin %s:cdc.statusInterval is invalid duration: %v", STORENAME, val)
+		}
+		cfg.statusInterval = d
+	}
+	if val, ok := obj["maxLagMB"]; ok {
+		size, ok := val.(int)
+		if !ok || size < 0 {
+			return nil, fmt.Errorf("config Plugin %s:cdc.maxLagMB must be a non-negative integer", STORENAME)
+		}
+		cfg.maxLagMB = size
+	}
+	return cfg, nil
+}
+
+// nodeCDCSlot 当前节点默认的复制槽，复制槽同时只能有一个消费方，每个节点使用自己的复制槽
+func nodeCDCSlot() string {
+	var sb strings.Builder
+	sb.WriteString(DefaultCDCSlot + "_")
+	for _, c := range strings.ToLower(utils.LocalHost) {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			c = '_'
+		}
+		sb.WriteRune(c)
+	}
+	slot := sb.String()
+	if len(slot) > 63 {
+		slot = slot[:63]
+	}
+	return slot
+}
+
+// isSimpleIdentifier 复制槽名称只允许小写字母、数字以及下划线
+func isSimpleIdentifier(name string) bool {
+	if name == "" || len(name) > 63 {
+		return false
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// cdcConsumer 逻辑复制消费方
+type cdcConsumer struct {
+	master *BaseDB
+	cfg    *cdcConfig
+	dsn    string
+	// schema 只处理当前 schema 下的表
+	schema string
+	// relations 复制协议中 relation id 与表结构的映射，每个连接重新建立
+	relations map[uint32]*relationMessage
+}
+
+func newCDCConsumer(master *BaseDB, cfg *cdcConfig) *cdcConsumer {
+	return &cdcConsumer{master: master, cfg: cfg, dsn: buildDSN(master.cfg) + " replication=database"}
+}
+
+// setup 创建发布以及复制槽，多个节点同时创建时忽略已存在的错误
+// 复制槽在第一次消费时才创建，没有消费方的节点不会让主库保留 WAL
+func (c *cdcConsumer) setup(ctx context.Context) error {
+	if err := c.master.QueryRowContext(ctx, "cdcConsumer.setup", "SELECT current_schema()").Scan(&c.schema); err != nil {
+		return err
+	}
+	var count int
+	err := c.master.QueryRowContext(ctx, "cdcConsumer.setup", "SELECT COUNT(*) FROM pg_publication WHERE pubname = $1",
+		c.cfg.publication).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		tables := make([]string, 0, len(cdcDecoders))
+		for table := range cdcDecoders {
+			tables = append(tables, quoteIdentifier(table))
+		}
+		_, err = c.master.ExecContext(ctx, "cdcConsumer.setup", fmt.Sprintf("CREATE PUBLICATION %s FOR TABLE %s",
+			quoteIdentifier(c.cfg.publication), strings.Join(tables, ", ")))
+		if err != nil && sqlState(err) != "42710" {
+			return err
+		}
+	}
+	if err = c.dropStaleSlots(ctx); err != nil {
+		return err
+	}
+	err = c.master.QueryRowContext(ctx, "cdcConsumer.setup",
+		"SELECT COUNT(*) FROM pg_replication_slots WHERE slot_name = $1", c.cfg.slot).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		log.Infof("[Store][database] create logical replication slot %s", c.cfg.slot)
+		_, err = c.master.ExecContext(ctx, "cdcConsumer.setup",
+			"SELECT pg_create_logical_replication_slot($1, 'pgoutput')", c.cfg.slot)
+		if err != nil && sqlState(err) != "42710" {
+			return err
+		}
+	}
+	return c.checkSlotLost(ctx)
+}
+
+// checkSlotLost 检查复制槽是否在已确认的位置之后被重新创建
+// 位置总是先持久化再上报给主库，复制槽的 confirmed_flush_lsn 不会超过已持久化的位置，超过时说明复制槽被删除后重新创建，
+// 两者之间的变更已经丢失，此时删除已持久化的位置并返回 ErrCDCSlotLost，由调用方全量加载后重新消费
+func (c *cdcConsumer) checkSlotLost(ctx context.Context) error {
+	var lost bool
+	err := c.master.QueryRowContext(ctx, "cdcConsumer.checkSlotLost",
+		"SELECT COALESCE(o.confirmed_lsn < s.confirmed_flush_lsn, false) FROM cdc_offset o "+
+			"JOIN pg_replication_slots s ON s.slot_name = o.slot_name WHERE o.slot_name = $1", c.cfg.slot).Scan(&lost)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+	if !lost {
+		return nil
+	}
+	log.Errorf("[Store][database] cdc replication slot %s was recreated after the saved offset, changes are lost",
+		c.cfg.slot)
+	if _, err := c.master.ExecContext(ctx, "cdcConsumer.checkSlotLost",
+		"DELETE FROM cdc_offset WHERE slot_name = $1", c.cfg.slot); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrCDCSlotLost, c.cfg.slot)
+}
+
+// dropStaleSlots 开始消费前，当前节点的复制槽保留的 WAL 超过 maxLagMB 时删除该复制槽，
+// 避免长时间停止消费的复制槽让主库无限保留 WAL。只处理当前节点配置的复制槽，其他节点的复制槽由对应的节点或者运维人员处理；
+// 已持久化的位置保留，重新创建复制槽后由 checkSlotLost 发现变更丢失
+func (c *cdcConsumer) dropStaleSlots(ctx context.Context) error {
+	if c.cfg.maxLagMB <= 0 {
+		return nil
+	}
+	var count int
+	err := c.master.QueryRowContext(ctx, "cdcConsumer.dropStaleSlots", "SELECT COUNT(*) FROM pg_replication_slots "+
+		"WHERE slot_name = $1 AND database = current_database() AND NOT active "+
+		"AND pg_wal_lsn_diff(pg_current_wal_lsn(), restart_lsn) > $2::bigint * 1024 * 1024",
+		c.cfg.slot, c.cfg.maxLagMB).Scan(&count)
+	if err != nil || count == 0 {
+		return err
+	}
+	log.Errorf("[Store][database] cdc replication slot %s is inactive and lags more than %dMB, drop it",
+		c.cfg.slot, c.cfg.maxLagMB)
+	_, err = c.master.ExecContext(ctx, "cdcConsumer.dropStaleSlots",
+		"SELECT pg_drop_replication_slot(slot_name) FROM pg_replication_slots "+
+			"WHERE slot_name = $1 AND NOT active", c.cfg.slot)
+	return err
+}
+
+// loadOffset 读取已持久化的确认位置，没有记录时返回0，由复制槽的 confirmed_flush_lsn 决定起点
+func (c *cdcConsumer) loadOffset(ctx context.Context) (LSN, error) {
+	var lsn string
+	err := c.master.QueryRowContext(ctx, "cdcConsumer.loadOffset",
+		"SELECT confirmed_lsn::text FROM cdc_offset WHERE slot_name = $1", c.cfg.slot).Scan(&lsn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return parseLSN(lsn)
+}
+
+// saveOffset 持久化确认位置
+func (c *cdcConsumer) saveOffset(ctx context.Context, lsn LSN) error {
+	_, err := c.master.ExecContext(ctx, "cdcConsumer.saveOffset",
+		"INSERT INTO cdc_offset (slot_name, confirmed_lsn, mtime) "+
+			"VALUES ($1, $2::pg_lsn, CURRENT_TIMESTAMP) ON CONFLICT (slot_name) "+
+			"DO UPDATE SET confirmed_lsn = EXCLUDED.confirmed_lsn, mtime = EXCLUDED.mtime", c.cfg.slot, lsn.String())
+	return err
+}
+
+// run 持续消费直到 ctx 结束，连接断开后从已确认的位置重新开始，复制槽被其他消费方使用时返回 ErrCDCSlotInUse，
+// 复制槽重新创建导致变更丢失时返回 ErrCDCSlotLost
+func (c *cdcConsumer) run(ctx context.Context, handler CDCHandler) error {
+	backoff := time.Second
+	for {
+		// 每次重连前确认复制槽存在，被删除的复制槽会重新创建并返回 ErrCDCSlotLost
+		err := c.setup(ctx)
+		if err == nil {
+			err = c.consume(ctx, handler)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, ErrCDCSlotLost) {
+			return err
+		}
+		if sqlState(err) == objectInUseCode {
+			log.Errorf("[Store][database] cdc replication slot %s is in use: %v", c.cfg.slot, err)
+			return fmt.Errorf("%w: %s", ErrCDCSlotInUse, c.cfg.slot)
+		}
+		log.Errorf("[Store][database] cdc consume err: %v, reconnect after %s", err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if backoff *= 2; backoff > changeFeedMaxBackoff {
+			backoff = changeFeedMaxBackoff
+		}
+	}
+}
+
+// consume 建立复制连接并处理变更，直到连接断开或者处理失败
+func (c *cdcConsumer) consume(ctx context.Context, handler CDCHandler) error {
+	confirmed, err := c.loadOffset(ctx)
+	if err != nil {
+		return err
+	}
+	conn, err := pgconn.Connect(ctx, c.dsn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close(context.Background())
+	}()
+	if err = startReplication(ctx, conn, c.cfg.slot, c.cfg.publication, confirmed); err != nil {
+		return err
+	}
+	log.Infof("[Store][database] cdc start replication slot %s from %s", c.cfg.slot, confirmed)
+
+	c.relations = map[uint32]*relationMessage{}
+	var (
+		// saved 已经持久化的位置，跳过的空事务只推进内存中的位置，在上报进度时一并持久化
+		saved      = confirmed
+		current    *CDCTransaction
+		nextStatus = time.Now().Add(c.cfg.statusInterval)
+	)
+	sendStatus := func() error {
+		if confirmed != saved {
+			if err := c.saveOffset(ctx, confirmed); err != nil {
+				return err
+			}
+			saved = confirmed
+		}
+		nextStatus = time.Now().Add(c.cfg.statusInterval)
+		return sendStandbyStatus(conn, confirmed)
+	}
+
+	for {
+		if !time.Now().Before(nextStatus) {
+			if err := sendStatus(); err != nil {
+				return err
+			}
+		}
+		recvCtx, cancel := context.WithDeadline(ctx, nextStatus)
+		msg, err := conn.ReceiveMessage(recvCtx)
+		cancel()
+		if err != nil {
+			if pgconn.Timeout(err) && ctx.Err() == nil {
+				continue
+			}
+			return err
+		}
+
+		switch msg := msg.(type) {
+		case *pgproto3.ErrorResponse:
+			return pgconn.ErrorResponseToPgError(msg)
+		case *pgproto3.CopyData:
+			if len(msg.Data) == 0 {
+				continue
+			}
+			switch msg.Data[0] {
+			case primaryKeepaliveByteID:
+				keepalive, err := parsePrimaryKeepalive(msg.Data[1:])
+				if err != nil {
+					return err
+				}
+				// 没有进行中的事务时，心跳中的位置之前的 WAL 都已经处理完毕
+				if current == nil && keepalive.walEnd > confirmed {
+					confirmed = keepalive.walEnd
+				}
+				if keepalive.replyRequested {
+					if err := sendStatus(); err != nil {
+						return err
+					}
+				}
+			case xLogDataByteID:
+				xld, err := parseXLogData(msg.Data[1:])
+				if err != nil {
+					return err
+				}
+				done, err := c.handleMessage(xld.data, &current)
+				if err != nil {
+					return err
+				}
+				if done == nil {
+					continue
+				}
+				if len(done.Events) > 0 {
+					if err := handler(done); err != nil {
+						return fmt.Errorf("handle transaction %s: %w", done.LSN, err)
+					}
+					if err := c.saveOffset(ctx, done.LSN); err != nil {
+						return err
+					}
+					saved = done.LSN
+				}
+				confirmed = done.LSN
+			}
+		}
+	}
+}
+
+// handleMessage 处理一条 pgoutput 消息，事务提交时返回完整的事务
+func (c *cdcConsumer) handleMessage(data []byte, current **CDCTransaction) (*CDCTransaction, error) {
+	msg, err := parsePgoutput(data)
+	if err != nil {
+		return nil, err
+	}
+	switch msg := msg.(type) {
+	case *beginMessage:
+		*current = &CDCTransaction{CommitTime: msg.commitTime}
+	case *commitMessage:
+		tx := *current
+		*current = nil
+		if tx == nil {
+			return nil, errors.New("commit message without begin")
+		}
+		tx.LSN = msg.endLSN
+		return tx, nil
+	case *relationMessage:
+		c.relations[msg.id] = msg
+	case *rowMessage:
+		rel, ok := c.relations[msg.relationID]
+		if !ok {
+			return nil, fmt.Errorf("unknown relation id %d", msg.relationID)
+		}
+		if *current == nil || rel.namespace != c.schema {
+			return nil, nil
+		}
+		tuple := msg.newTuple
+		if msg.op == "DELETE" {
+			tuple = msg.oldTuple
+		}
+		(*current).Events = append((*current).Events, newCDCEvent(rel, msg.op, tuple))
+	case *truncateMessage:
+		for _, id := range msg.relationIDs {
+			if rel, ok := c.relations[id]; ok && *current != nil && rel.namespace == c.schema {
+				(*current).Events = append((*current).Events, &CDCEvent{Table: rel.name, Op: "TRUNCATE"})
+			}
+		}
+	}
+	return nil, nil
+}
+
+// newCDCEvent 按照表结构将行数据转换为列名与值的映射，并解码为对应的模型
+func newCDCEvent(rel *relationMessage, op string, tuple []*string) *CDCEvent {
+	row := make(cdcRow, len(tuple))
+	for i, val := range tuple {
+		if i >= len(rel.columns) || val == unchangedToast {
+			continue
+		}
+		row[rel.columns[i]] = val
+	}
+	event := &CDCEvent{Table: rel.name, Op: op, Columns: row}
+	if decoder, ok := cdcDecoders[rel.name]; ok {
+		event.Object = decoder(row)
+	}
+	return event
+}
+
+// startReplication 发送 START_REPLICATION 并等待进入 COPY BOTH 模式
+func startReplication(ctx context.Context, conn *pgconn.PgConn, slot, publication string, start LSN) error {
+	sql := fmt.Sprintf("START_REPLICATION SLOT %s LOGICAL %s (proto_version '1', publication_names '%s')",
+		quoteIdentifier(slot), start, publication)
+	conn.Frontend().SendQuery(&pgproto3.Query{String: sql})
+	if err := conn.Frontend().Flush(); err != nil {
+		return err
+	}
+	for {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			return err
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.CopyBothResponse:
+			return nil
+		case *pgproto3.ErrorResponse:
+			return pgconn.ErrorResponseToPgError(msg)
+		}
+	}
+}
+
+// sendStandbyStatus 上报已确认的位置，主库据此回收 WAL
+func sendStandbyStatus(conn *pgconn.PgConn, confirmed LSN) error {
+	data, err := (&pgproto3.CopyData{Data: encodeStandbyStatus(confirmed, time.Now())}).Encode(nil)
+	if err != nil {
+		return err
+	}
+	return conn.Frontend().SendUnbufferedEncodedCopyData(data)
+}
+
+// cdcRow 列名与文本格式的值
+type cdcRow map[string]*string
+
+func (r cdcRow) str(col string) string {
+	if v := r[col]; v != nil {
+		return *v
+	}
+	return ""
+}
+
+func (r cdcRow) int(col string) int64 {
+	v, _ := strconv.ParseInt(r.str(col), 10, 64)
+	return v
+}
+
+// valid flag 为0表示未被逻辑删除
+func (r cdcRow) valid() bool {
+	return r.int("flag") == 0
+}
+
+func (r cdcRow) time(col string) time.Time {
+	val := r.str(col)
+	for _, layout := range cdcTimezoneLayouts {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t
+		}
+	}
+	t, _ := time.ParseInLocation(cdcTimeLayout, val, GetLocation())
+	return t
+}
+
+func (r cdcRow) metadata(col string) map[string]string {
+	ret := map[string]string{}
+	if val := r.str(col); val != "" {
+		_ = json.Unmarshal([]byte(val), &ret)
+	}
+	return ret
+}
+
+func decodeCDCService(r cdcRow) interface{} {
+	ctime, mtime := r.time("ctime"), r.time("mtime")
+	return &model.Service{
+		ID: r.str("id"), Name: r.str("name"), Namespace: r.str("namespace"), Business: r.str("business"),
+		Ports: r.str("ports"), Comment: r.str("comment"), Department: r.str("department"),
+		CmdbMod1: r.str("cmdb_mod1"), CmdbMod2: r.str("cmdb_mod2"), CmdbMod3: r.str("cmdb_mod3"),
+		Token: r.str("token"), Owner: r.str("owner"), Revision: r.str("revision"), Reference: r.str("reference"),
+		ReferFilter: r.str("refer_filter"), PlatformID: r.str("platform_id"), Valid: r.valid(),
+		CreateTime: ctime, ModifyTime: mtime, Ctime: ctime.Unix(), Mtime: mtime.Unix(),
+	}
+}
+
+func decodeCDCInstance(r cdcRow) interface{} {
+	return model.Store2Instance(&model.InstanceStore{
+		ID: r.str("id"), ServiceID: r.str("service_id"), VpcID: r.str("vpc_id"), Host: r.str("host"),
+		Port: uint32(r.int("port")), Protocol: r.str("protocol"), Version: r.str("version"),
+		HealthStatus: int(r.int("health_status")), Isolate: int(r.int("isolate")), Weight: uint32(r.int("weight")),
+		EnableHealthCheck: int(r.int("enable_health_check")), LogicSet: r.str("logic_set"),
+		Region: r.str("cmdb_region"), Zone: r.str("cmdb_zone"), Campus: r.str("cmdb_idc"),
+		Priority: uint32(r.int("priority")), Revision: r.str("revision"), Flag: int(r.int("flag")),
+		CreateTime: r.time("ctime").Unix(), ModifyTime: r.time("mtime").Unix(),
+	})
+}
+
+func decodeCDCConfigFileRelease(r cdcRow) interface{} {
+	return &model.ConfigFileRelease{
+		SimpleConfigFileRelease: &model.SimpleConfigFileRelease{
+			ConfigFileReleaseKey: &model.ConfigFileReleaseKey{
+				Id: uint64(r.int("id")), Name: r.str("name"), Namespace: r.str("namespace"), Group: r.str("group"),
+				FileName: r.str("file_name"), ReleaseType: model.ReleaseType(r.str("release_type")),
+			},
+			Version: uint64(r.int("version")), Comment: r.str("comment"), Md5: r.str("md5"),
+			Flag: int(r.int("flag")), Active: r.int("active") == 1, Valid: r.valid(), Format: r.str("format"),
+			Metadata: r.metadata("tags"), CreateTime: r.time("create_time"), CreateBy: r.str("create_by"),
+			ModifyTime: r.time("modify_time"), ModifyBy: r.str("modify_by"), ReleaseDescription: r.str("description"),
+		},
+		Content: r.str("content"),
+	}
+}
+
+func decodeCDCRouterConfig(r cdcRow) interface{} {
+	return &model.RouterConfig{
+		ID: r.str("id"), Namespace: r.str("namespace"), Name: r.str("name"), Policy: r.str("policy"),
+		Config: r.str("config"), Enable: r.int("enable") == 1, Priority: uint32(r.int("priority")),
+		Revision: r.str("revision"), Description: r.str("description"), Valid: r.valid(),
+		CreateTime: r.time("ctime"), ModifyTime: r.time("mtime"), EnableTime: r.time("etime"),
+	}
+}
+
+func decodeCDCRateLimit(r cdcRow) interface{} {
+	return &model.RateLimit{
+		ID: r.str("id"), ServiceID: r.str("service_id"), Name: r.str("name"), Method: r.str("method"),
+		Labels: r.str("labels"), Priority: uint32(r.int("priority")), Rule: r.str("rule"),
+		Revision: r.str("revision"), Disable: r.int("disable") == 1, Valid: r.valid(),
+		CreateTime: r.time("ctime"), ModifyTime: r.time("mtime"), EnableTime: r.time("etime"),
+	}
+}
+
+func decodeCDCCircuitBreakerRule(r cdcRow) interface{} {
+	return &model.CircuitBreakerRule{
+		ID: r.str("id"), Name: r.str("name"), Namespace: r.str("namespace"), Description: r.str("description"),
+		Level: int(r.int("level")), SrcService: r.str("src_service"), SrcNamespace: r.str("src_namespace"),
+		DstService: r.str("dst_service"), DstNamespace: r.str("dst_namespace"), DstMethod: r.str("dst_method"),
+		Rule: r.str("config"), Revision: r.str("revision"), Enable: r.int("enable") == 1, Valid: r.valid(),
+		CreateTime: r.time("ctime"), ModifyTime: r.time("mtime"), EnableTime: r.time("etime"),
+	}
+}
+
+func decodeCDCFaultDetectRule(r cdcRow) interface{} {
+	return &model.FaultDetectRule{
+		ID: r.str("id"), Name: r.str("name"), Namespace: r.str("namespace"), Description: r.str("description"),
+		DstService: r.str("dst_service"), DstNamespace: r.str("dst_namespace"), DstMethod: r.str("dst_method"),
+		Rule: r.str("config"), Revision: r.str("revision"), Valid: r.valid(),
+		CreateTime: r.time("ctime"), ModifyTime: r.time("mtime"),
+	}
+}
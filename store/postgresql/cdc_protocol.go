@@ -0,0 +1,286 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 流复制协议以及 pgoutput 逻辑解码协议（proto_version 1）的编解码
+// 参考 https://www.postgresql.org/docs/current/protocol-replication.html
+// 以及 https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+
+const (
+	// xLogDataByteID 流复制的 WAL 数据
+	xLogDataByteID = 'w'
+	// primaryKeepaliveByteID 主库的心跳
+	primaryKeepaliveByteID = 'k'
+	// standbyStatusUpdateByteID 备库（消费方）上报的消费进度
+	standbyStatusUpdateByteID = 'r'
+)
+
+// pgEpoch PostgreSQL 协议中时间戳的起点，单位微秒
+var pgEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var errShortMessage = errors.New("logical replication message too short")
+
+// LSN WAL 日志的位置
+type LSN uint64
+
+// String 与 pg_lsn 的文本格式一致，如 16/B374D848
+func (l LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(l>>32), uint32(l))
+}
+
+// parseLSN 解析 pg_lsn 的文本格式
+func parseLSN(s string) (LSN, error) {
+	var upper, lower uint32
+	if _, err := fmt.Sscanf(s, "%X/%X", &upper, &lower); err != nil {
+		return 0, fmt.Errorf("invalid lsn %s", s)
+	}
+	return LSN(uint64(upper)<<32 | uint64(lower)), nil
+}
+
+func pgTime(micros int64) time.Time {
+	return pgEpoch.Add(time.Duration(micros) * time.Microsecond)
+}
+
+// msgReader 按网络字节序读取消息，越界时记录错误并返回零值
+type msgReader struct {
+	buf []byte
+	err error
+}
+
+func (r *msgReader) byte() byte {
+	if r.err != nil || len(r.buf) < 1 {
+		r.err = errShortMessage
+		return 0
+	}
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *msgReader) uint16() uint16 {
+	if r.err != nil || len(r.buf) < 2 {
+		r.err = errShortMessage
+		return 0
+	}
+	v := binary.BigEndian.Uint16(r.buf)
+	r.buf = r.buf[2:]
+	return v
+}
+
+func (r *msgReader) uint32() uint32 {
+	if r.err != nil || len(r.buf) < 4 {
+		r.err = errShortMessage
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.buf)
+	r.buf = r.buf[4:]
+	return v
+}
+
+func (r *msgReader) uint64() uint64 {
+	if r.err != nil || len(r.buf) < 8 {
+		r.err = errShortMessage
+		return 0
+	}
+	v := binary.BigEndian.Uint64(r.buf)
+	r.buf = r.buf[8:]
+	return v
+}
+
+func (r *msgReader) bytes(n int) []byte {
+	if r.err != nil || n < 0 || len(r.buf) < n {
+		r.err = errShortMessage
+		return nil
+	}
+	v := r.buf[:n]
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *msgReader) cstring() string {
+	for i, b := range r.buf {
+		if b == 0 {
+			s := string(r.buf[:i])
+			r.buf = r.buf[i+1:]
+			return s
+		}
+	}
+	r.err = errShortMessage
+	return ""
+}
+
+// xLogData 流复制的 WAL 数据
+type xLogData struct {
+	walStart LSN
+	walEnd   LSN
+	data     []byte
+}
+
+func parseXLogData(buf []byte) (*xLogData, error) {
+	r := &msgReader{buf: buf}
+	msg := &xLogData{walStart: LSN(r.uint64()), walEnd: LSN(r.uint64())}
+	_ = r.uint64()
+	msg.data = r.buf
+	return msg, r.err
+}
+
+// primaryKeepalive 主库的心跳，replyRequested 为 true 时需要立即上报消费进度
+type primaryKeepalive struct {
+	walEnd         LSN
+	replyRequested bool
+}
+
+func parsePrimaryKeepalive(buf []byte) (*primaryKeepalive, error) {
+	r := &msgReader{buf: buf}
+	msg := &primaryKeepalive{walEnd: LSN(r.uint64())}
+	_ = r.uint64()
+	msg.replyRequested = r.byte() == 1
+	return msg, r.err
+}
+
+// encodeStandbyStatus 上报消费进度，写入、刷盘以及应用位置均为已确认的位置
+func encodeStandbyStatus(confirmed LSN, now time.Time) []byte {
+	buf := make([]byte, 0, 34)
+	buf = append(buf, standbyStatusUpdateByteID)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(confirmed))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(confirmed))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(confirmed))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(now.Sub(pgEpoch).Microseconds()))
+	return append(buf, 0)
+}
+
+// pgoutput 消息
+type (
+	beginMessage struct {
+		finalLSN   LSN
+		commitTime time.Time
+		xid        uint32
+	}
+	commitMessage struct {
+		commitLSN  LSN
+		endLSN     LSN
+		commitTime time.Time
+	}
+	relationMessage struct {
+		id        uint32
+		namespace string
+		name      string
+		columns   []string
+	}
+	// rowMessage INSERT、UPDATE 以及 DELETE，oldTuple 仅在 replica identity 包含的列发生变更或者删除时存在
+	rowMessage struct {
+		op         string
+		relationID uint32
+		oldTuple   []*string
+		newTuple   []*string
+	}
+	truncateMessage struct {
+		relationIDs []uint32
+	}
+)
+
+// unchangedToast 未发生变更的 TOAST 列，pgoutput 不发送其内容
+var unchangedToast = new(string)
+
+// parsePgoutput 解析 pgoutput 消息，不关心的消息类型返回 nil
+func parsePgoutput(buf []byte) (interface{}, error) {
+	r := &msgReader{buf: buf}
+	var msg interface{}
+	switch r.byte() {
+	case 'B':
+		msg = &beginMessage{finalLSN: LSN(r.uint64()), commitTime: pgTime(int64(r.uint64())), xid: r.uint32()}
+	case 'C':
+		_ = r.byte()
+		msg = &commitMessage{commitLSN: LSN(r.uint64()), endLSN: LSN(r.uint64()),
+			commitTime: pgTime(int64(r.uint64()))}
+	case 'R':
+		rel := &relationMessage{id: r.uint32(), namespace: r.cstring(), name: r.cstring()}
+		_ = r.byte()
+		n := int(r.uint16())
+		for i := 0; i < n && r.err == nil; i++ {
+			_ = r.byte()
+			rel.columns = append(rel.columns, r.cstring())
+			_ = r.uint32()
+			_ = r.uint32()
+		}
+		msg = rel
+	case 'I':
+		row := &rowMessage{op: "INSERT", relationID: r.uint32()}
+		if r.byte() != 'N' && r.err == nil {
+			return nil, errors.New("insert message without new tuple")
+		}
+		row.newTuple = r.tuple()
+		msg = row
+	case 'U':
+		row := &rowMessage{op: "UPDATE", relationID: r.uint32()}
+		kind := r.byte()
+		if kind == 'K' || kind == 'O' {
+			row.oldTuple = r.tuple()
+			kind = r.byte()
+		}
+		if kind != 'N' && r.err == nil {
+			return nil, errors.New("update message without new tuple")
+		}
+		row.newTuple = r.tuple()
+		msg = row
+	case 'D':
+		row := &rowMessage{op: "DELETE", relationID: r.uint32()}
+		if kind := r.byte(); kind != 'K' && kind != 'O' && r.err == nil {
+			return nil, errors.New("delete message without old tuple")
+		}
+		row.oldTuple = r.tuple()
+		msg = row
+	case 'T':
+		n := int(r.uint32())
+		_ = r.byte()
+		trunc := &truncateMessage{}
+		for i := 0; i < n && r.err == nil; i++ {
+			trunc.relationIDs = append(trunc.relationIDs, r.uint32())
+		}
+		msg = trunc
+	}
+	return msg, r.err
+}
+
+// tuple 解析行数据，NULL 为 nil，未变更的 TOAST 列为 unchangedToast
+func (r *msgReader) tuple() []*string {
+	n := int(r.uint16())
+	values := make([]*string, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		switch kind := r.byte(); kind {
+		case 'n':
+			values = append(values, nil)
+		case 'u':
+			values = append(values, unchangedToast)
+		case 't', 'b':
+			v := string(r.bytes(int(r.uint32())))
+			values = append(values, &v)
+		default:
+			if r.err == nil {
+				r.err = fmt.Errorf("unknown tuple data kind %c", kind)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,218 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/polarismesh/polaris/common/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// pgoutputBuilder 构造 pgoutput 消息
+type pgoutputBuilder []byte
+
+func (b pgoutputBuilder) byte(v byte) pgoutputBuilder  { return append(b, v) }
+func (b pgoutputBuilder) u16(v uint16) pgoutputBuilder { return binary.BigEndian.AppendUint16(b, v) }
+func (b pgoutputBuilder) u32(v uint32) pgoutputBuilder { return binary.BigEndian.AppendUint32(b, v) }
+func (b pgoutputBuilder) u64(v uint64) pgoutputBuilder { return binary.BigEndian.AppendUint64(b, v) }
+func (b pgoutputBuilder) str(v string) pgoutputBuilder { return append(append(b, v...), 0) }
+
+// tuple 构造行数据，nil 为 NULL，"\x00toast" 为未变更的 TOAST 列
+func (b pgoutputBuilder) tuple(values ...*string) pgoutputBuilder {
+	b = b.u16(uint16(len(values)))
+	for _, v := range values {
+		switch {
+		case v == nil:
+			b = b.byte('n')
+		case *v == "\x00toast":
+			b = b.byte('u')
+		default:
+			b = b.byte('t').u32(uint32(len(*v)))
+			b = append(b, *v...)
+		}
+	}
+	return b
+}
+
+func relationMsg(id uint32, namespace, name string, columns ...string) []byte {
+	b := pgoutputBuilder{}.byte('R').u32(id).str(namespace).str(name).byte('d').u16(uint16(len(columns)))
+	for i, col := range columns {
+		flag := byte(0)
+		if i == 0 {
+			flag = 1
+		}
+		b = b.byte(flag).str(col).u32(25).u32(0xffffffff)
+	}
+	return b
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestLSN(t *testing.T) {
+	Convey("LSN 与 pg_lsn 文本格式互相转换", t, func() {
+		lsn, err := parseLSN("16/B374D848")
+		So(err, ShouldBeNil)
+		So(lsn, ShouldEqual, LSN(0x16B374D848))
+		So(lsn.String(), ShouldEqual, "16/B374D848")
+		So(LSN(0).String(), ShouldEqual, "0/0")
+		_, err = parseLSN("abc")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestParseReplicationMessages(t *testing.T) {
+	Convey("解析心跳以及 WAL 数据", t, func() {
+		keepalive, err := parsePrimaryKeepalive(pgoutputBuilder{}.u64(100).u64(0).byte(1))
+		So(err, ShouldBeNil)
+		So(keepalive.walEnd, ShouldEqual, LSN(100))
+		So(keepalive.replyRequested, ShouldBeTrue)
+
+		xld, err := parseXLogData(append(pgoutputBuilder{}.u64(10).u64(20).u64(0), 'B'))
+		So(err, ShouldBeNil)
+		So(xld.walStart, ShouldEqual, LSN(10))
+		So(xld.data, ShouldResemble, []byte{'B'})
+
+		_, err = parsePrimaryKeepalive([]byte{1, 2})
+		So(err, ShouldNotBeNil)
+	})
+	Convey("上报消费进度", t, func() {
+		now := pgEpoch.Add(time.Second)
+		buf := encodeStandbyStatus(LSN(42), now)
+		So(len(buf), ShouldEqual, 34)
+		So(buf[0], ShouldEqual, standbyStatusUpdateByteID)
+		So(binary.BigEndian.Uint64(buf[1:]), ShouldEqual, 42)
+		So(binary.BigEndian.Uint64(buf[17:]), ShouldEqual, 42)
+		So(binary.BigEndian.Uint64(buf[25:]), ShouldEqual, 1000000)
+	})
+	Convey("解析 pgoutput 消息", t, func() {
+		msg, err := parsePgoutput(relationMsg(7, "polaris", "service", "id", "name"))
+		So(err, ShouldBeNil)
+		So(msg, ShouldResemble, &relationMessage{id: 7, namespace: "polaris", name: "service",
+			columns: []string{"id", "name"}})
+
+		msg, err = parsePgoutput(pgoutputBuilder{}.byte('U').u32(7).byte('K').tuple(strPtr("old"), nil).
+			byte('N').tuple(strPtr("new"), strPtr("\x00toast")))
+		So(err, ShouldBeNil)
+		row := msg.(*rowMessage)
+		So(row.op, ShouldEqual, "UPDATE")
+		So(*row.oldTuple[0], ShouldEqual, "old")
+		So(row.oldTuple[1], ShouldBeNil)
+		So(*row.newTuple[0], ShouldEqual, "new")
+		So(row.newTuple[1], ShouldEqual, unchangedToast)
+
+		msg, err = parsePgoutput(pgoutputBuilder{}.byte('T').u32(2).byte(0).u32(7).u32(8))
+		So(err, ShouldBeNil)
+		So(msg.(*truncateMessage).relationIDs, ShouldResemble, []uint32{7, 8})
+
+		msg, err = parsePgoutput([]byte{'Y', 0})
+		So(err, ShouldBeNil)
+		So(msg, ShouldBeNil)
+
+		_, err = parsePgoutput(pgoutputBuilder{}.byte('I').u32(7).byte('N').u16(1).byte('t').u32(10))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestCDCHandleMessage(t *testing.T) {
+	Convey("按事务组装变更并解码为模型", t, func() {
+		c := &cdcConsumer{schema: "polaris", relations: map[uint32]*relationMessage{}}
+		var current *CDCTransaction
+		messages := [][]byte{
+			relationMsg(1, "polaris", "service", "id", "name", "namespace", "flag", "mtime"),
+			relationMsg(2, "polaris", "instance", "id", "service_id", "host", "port", "flag"),
+			relationMsg(3, "other", "service", "id", "name"),
+			pgoutputBuilder{}.byte('B').u64(90).u64(0).u32(1),
+			pgoutputBuilder{}.byte('I').u32(1).byte('N').tuple(strPtr("s1"), strPtr("svc"), strPtr("default"),
+				strPtr("0"), strPtr("2024-10-01 12:00:00.5")),
+			pgoutputBuilder{}.byte('D').u32(2).byte('K').tuple(strPtr("i1"), nil, nil, nil, nil),
+			pgoutputBuilder{}.byte('I').u32(3).byte('N').tuple(strPtr("s2"), strPtr("ignored")),
+		}
+		for _, data := range messages {
+			done, err := c.handleMessage(data, &current)
+			So(err, ShouldBeNil)
+			So(done, ShouldBeNil)
+		}
+		done, err := c.handleMessage(pgoutputBuilder{}.byte('C').byte(0).u64(90).u64(100).u64(0), &current)
+		So(err, ShouldBeNil)
+		So(current, ShouldBeNil)
+		So(done.LSN, ShouldEqual, LSN(100))
+		So(len(done.Events), ShouldEqual, 2)
+
+		svc := done.Events[0].Object.(*model.Service)
+		So(done.Events[0].Op, ShouldEqual, "INSERT")
+		So(svc.ID, ShouldEqual, "s1")
+		So(svc.Namespace, ShouldEqual, "default")
+		So(svc.Valid, ShouldBeTrue)
+		So(svc.ModifyTime, ShouldEqual, time.Date(2024, 10, 1, 12, 0, 0, 500000000, GetLocation()))
+
+		So(done.Events[1].Op, ShouldEqual, "DELETE")
+		So(done.Events[1].Object.(*model.Instance).ID(), ShouldEqual, "i1")
+		So(done.Events[1].Columns["host"], ShouldBeNil)
+	})
+	Convey("timestamptz 列按照文本中的时区偏移解析", t, func() {
+		row := cdcRow{"a": strPtr("2024-10-01 12:00:00.5+08"), "b": strPtr("2024-10-01 06:30:00+05:30")}
+		So(row.time("a").Equal(time.Date(2024, 10, 1, 4, 0, 0, 500000000, time.UTC)), ShouldBeTrue)
+		So(row.time("b").Equal(time.Date(2024, 10, 1, 1, 0, 0, 0, time.UTC)), ShouldBeTrue)
+	})
+	Convey("未知的 relation", t, func() {
+		c := &cdcConsumer{schema: "polaris", relations: map[uint32]*relationMessage{}}
+		current := &CDCTransaction{}
+		_, err := c.handleMessage(pgoutputBuilder{}.byte('I').u32(9).byte('N').tuple(strPtr("x")), &current)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestParseCDCConfig(t *testing.T) {
+	Convey("解析逻辑复制配置", t, func() {
+		cfg, err := parseCDCConfig(nil)
+		So(err, ShouldBeNil)
+		So(cfg.enable, ShouldBeFalse)
+		So(cfg.slot, ShouldEqual, nodeCDCSlot())
+		So(cfg.maxLagMB, ShouldEqual, 0)
+
+		cfg, err = parseCDCConfig(map[interface{}]interface{}{"enable": true, "slot": "polaris_a",
+			"publication": "pub_a", "statusInterval": "5s", "maxLagMB": 1024})
+		So(err, ShouldBeNil)
+		So(*cfg, ShouldResemble, cdcConfig{enable: true, slot: "polaris_a", publication: "pub_a",
+			statusInterval: 5 * time.Second, maxLagMB: 1024})
+
+		for _, opts := range []interface{}{
+			"on",
+			map[interface{}]interface{}{"slot": "Polaris"},
+			map[interface{}]interface{}{"publication": "a'b"},
+			map[interface{}]interface{}{"statusInterval": "0s"},
+			map[interface{}]interface{}{"maxLagMB": -1},
+			map[interface{}]interface{}{"maxLagMB": "1GB"},
+		} {
+			_, err = parseCDCConfig(opts)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestNodeCDCSlot(t *testing.T) {
+	Convey("每个节点默认使用自己的复制槽", t, func() {
+		slot := nodeCDCSlot()
+		So(slot, ShouldStartWith, DefaultCDCSlot+"_")
+		So(isSimpleIdentifier(slot), ShouldBeTrue)
+	})
+}
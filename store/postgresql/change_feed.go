@@ -0,0 +1,330 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// changeChannel 数据变更通知的频道，由 syncChangeTriggers 创建的触发器发送
+	changeChannel = "polaris_change"
+	// DefaultChangeFeedBufferSize 每个订阅方缓存的变更事件个数
+	DefaultChangeFeedBufferSize = 1024
+	// changeFeedMaxBackoff 监听连接断开后重连的最大间隔
+	changeFeedMaxBackoff = 30 * time.Second
+)
+
+// changeFeedTables 发送变更通知的表及其主键列，为 cache 增量查询涉及的表
+var changeFeedTables = map[string]string{
+	"namespace": "name", "service": "id", "instance": "id", "client": "id",
+	"routing_config": "id", "routing_config_v2": "id", "ratelimit_config": "id",
+	"circuitbreaker_rule_v2": "id", "fault_detect_rule": "id", "service_contract": "id",
+	"config_file_group": "id", "config_file_release": "id", "gray_resource": "name",
+	"user": "id", "user_group": "id", "auth_strategy": "id",
+}
+
+// changeTriggerOps 每张表的语句级触发器，使用转换表的触发器只能对应一种操作
+var changeTriggerOps = map[string]string{
+	"insert": "AFTER INSERT ON %s REFERENCING NEW TABLE AS polaris_new",
+	"update": "AFTER UPDATE ON %s REFERENCING OLD TABLE AS polaris_old NEW TABLE AS polaris_new",
+	"delete": "AFTER DELETE ON %s REFERENCING OLD TABLE AS polaris_old",
+}
+
+// ChangeEvent 数据变更事件
+type ChangeEvent struct {
+	// Table 发生变更的表
+	Table string `json:"table"`
+	// Op INSERT、UPDATE 或者 DELETE
+	Op string `json:"op"`
+	// Key 变更记录的主键，一条语句变更的记录过多时为空，订阅方需要按 mtime 增量拉取该表
+	Key string `json:"key"`
+	// Resync 监听连接重建或者订阅方消费过慢时，期间的变更可能丢失，订阅方需要按 mtime 增量拉取一次
+	Resync bool `json:"-"`
+}
+
+// changeNotify 触发器发送的消息，一条语句的变更合并为一条消息
+type changeNotify struct {
+	ChangeEvent
+	Schema string   `json:"schema"`
+	Keys   []string `json:"keys"`
+}
+
+// changeFeedConfig 变更通知配置
+type changeFeedConfig struct {
+	enable     bool
+	bufferSize int
+}
+
+// parseChangeFeedConfig 解析变更通知配置，默认不开启
+func parseChangeFeedConfig(opts interface{}) (*changeFeedConfig, error) {
+	cfg := &changeFeedConfig{bufferSize: DefaultChangeFeedBufferSize}
+	if opts == nil {
+		return cfg, nil
+	}
+	obj, ok := opts.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config Plugin %s:changeFeed type must be map", STORENAME)
+	}
+	if val, ok := obj["enable"]; ok {
+		if cfg.enable, ok = val.(bool); !ok {
+			return nil, fmt.Errorf("config Plugin %s:changeFeed.enable type must be bool", STORENAME)
+		}
+	}
+	if val, ok := obj["bufferSize"]; ok {
+		size, ok := val.(int)
+		if !ok || size <= 0 {
+			return nil, fmt.Errorf("config Plugin %s:changeFeed.bufferSize must be a positive integer", STORENAME)
+		}
+		cfg.bufferSize = size
+	}
+	return cfg, nil
+}
+
+// changeSubscriber 单个订阅方
+type changeSubscriber struct {
+	ch     chan ChangeEvent
+	tables map[string]struct{}
+	// lost 是否有事件因为订阅方消费过慢被丢弃
+	lost bool
+}
+
+// deliver 非阻塞地投递事件，丢弃事件后在下一次投递前先补发 Resync 事件
+func (s *changeSubscriber) deliver(event ChangeEvent) {
+	if !event.Resync && len(s.tables) > 0 {
+		if _, ok := s.tables[event.Table]; !ok {
+			return
+		}
+	}
+	if s.lost {
+		select {
+		case s.ch <- ChangeEvent{Resync: true}:
+			s.lost = false
+		default:
+			return
+		}
+		if event.Resync {
+			return
+		}
+	}
+	select {
+	case s.ch <- event:
+	default:
+		s.lost = true
+	}
+}
+
+// changeFeed 通过 LISTEN 接收数据变更通知并分发给订阅方
+type changeFeed struct {
+	dsn        string
+	bufferSize int
+
+	lock        sync.Mutex
+	subscribers map[*changeSubscriber]struct{}
+}
+
+func newChangeFeed(dsn string, bufferSize int) *changeFeed {
+	if bufferSize <= 0 {
+		bufferSize = DefaultChangeFeedBufferSize
+	}
+	return &changeFeed{dsn: dsn, bufferSize: bufferSize, subscribers: map[*changeSubscriber]struct{}{}}
+}
+
+// subscribe 订阅指定表的变更，tables 为空时订阅所有表，返回的函数用于取消订阅
+func (f *changeFeed) subscribe(tables ...string) (<-chan ChangeEvent, func()) {
+	sub := &changeSubscriber{ch: make(chan ChangeEvent, f.bufferSize), tables: map[string]struct{}{}}
+	for _, table := range tables {
+		sub.tables[table] = struct{}{}
+	}
+	f.lock.Lock()
+	f.subscribers[sub] = struct{}{}
+	f.lock.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			f.lock.Lock()
+			delete(f.subscribers, sub)
+			f.lock.Unlock()
+			close(sub.ch)
+		})
+	}
+}
+
+// publish 将事件分发给所有订阅方
+func (f *changeFeed) publish(event ChangeEvent) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for sub := range f.subscribers {
+		sub.deliver(event)
+	}
+}
+
+// run 监听变更通知，连接断开后按指数退避重连，重连成功后通知订阅方重新同步
+func (f *changeFeed) run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		connected, err := f.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = time.Second
+		}
+		log.Errorf("[Store][database] change feed listen err: %v, reconnect after %s", err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		if backoff *= 2; backoff > changeFeedMaxBackoff {
+			backoff = changeFeedMaxBackoff
+		}
+	}
+}
+
+// listen 建立监听连接并持续接收通知，返回是否曾经成功建立监听
+func (f *changeFeed) listen(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, f.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = conn.Close(context.Background())
+	}()
+
+	// 同一个数据库的多个 schema 共用一个频道，只处理当前 schema 的变更
+	var schema string
+	if err = conn.QueryRow(ctx, "SELECT current_schema()").Scan(&schema); err != nil {
+		return false, err
+	}
+	if _, err = conn.Exec(ctx, "LISTEN "+changeChannel); err != nil {
+		return false, err
+	}
+	log.Infof("[Store][database] change feed listen on channel %s, schema %s", changeChannel, schema)
+	f.publish(ChangeEvent{Resync: true})
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+		for _, event := range parseChangeNotify(notification.Payload, schema) {
+			f.publish(event)
+		}
+	}
+}
+
+// parseChangeNotify 解析触发器发送的消息，按主键拆分为多个事件，忽略其他 schema 的变更
+func parseChangeNotify(payload, schema string) []ChangeEvent {
+	notify := &changeNotify{}
+	if err := json.Unmarshal([]byte(payload), notify); err != nil {
+		log.Warnf("[Store][database] change feed invalid payload %s: %s", payload, err.Error())
+		return nil
+	}
+	if notify.Schema != schema {
+		return nil
+	}
+	if len(notify.Keys) == 0 {
+		return []ChangeEvent{notify.ChangeEvent}
+	}
+	events := make([]ChangeEvent, 0, len(notify.Keys))
+	for _, key := range notify.Keys {
+		event := notify.ChangeEvent
+		event.Key = key
+		events = append(events, event)
+	}
+	return events
+}
+
+// changeTriggerSql 创建表的变更通知触发器
+func changeTriggerSql(table, op string) string {
+	return fmt.Sprintf("CREATE TRIGGER %s "+changeTriggerOps[op]+
+		" FOR EACH STATEMENT EXECUTE FUNCTION polaris_notify_change_stmt('%s')",
+		quoteIdentifier(table+"_notify_"+op), quoteIdentifier(table), changeFeedTables[table])
+}
+
+// syncChangeTriggers 按 changeFeed.enable 创建或者删除变更通知触发器，未开启时写入不需要承担 NOTIFY 的开销
+// 触发器作用于整个 schema，同一个 schema 的所有节点需要使用相同的 changeFeed 配置
+func (b *BaseDB) syncChangeTriggers(ctx context.Context, enable bool) error {
+	rows, err := b.QueryContext(ctx, "BaseDB.syncChangeTriggers",
+		"SELECT c.relname, COALESCE(t.tgname, '') FROM pg_class c "+
+			"JOIN pg_namespace n ON n.oid = c.relnamespace "+
+			"LEFT JOIN pg_trigger t ON t.tgrelid = c.oid AND NOT t.tgisinternal "+
+			"WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p')")
+	if err != nil {
+		return err
+	}
+	// existing 表名 -> 是否存在，表名.触发器名 -> 是否存在
+	existing := map[string]bool{}
+	for rows.Next() {
+		var table, trigger string
+		if err := rows.Scan(&table, &trigger); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		existing[table] = true
+		if trigger != "" {
+			existing[table+"."+trigger] = true
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	tables := make([]string, 0, len(changeFeedTables))
+	for table := range changeFeedTables {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	var stmts []string
+	for _, table := range tables {
+		if !existing[table] {
+			continue
+		}
+		for _, op := range []string{"insert", "update", "delete"} {
+			trigger := table + "_notify_" + op
+			switch {
+			case enable && !existing[table+"."+trigger]:
+				stmts = append(stmts, changeTriggerSql(table, op))
+			case !enable && existing[table+"."+trigger]:
+				stmts = append(stmts, fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s",
+					quoteIdentifier(trigger), quoteIdentifier(table)))
+			}
+		}
+	}
+	if len(stmts) == 0 {
+		return nil
+	}
+	log.Infof("[Store][database] sync change feed triggers, enable: %t, statements: %d", enable, len(stmts))
+	return b.processWithTransactionContext(ctx, opWrite, "syncChangeTriggers", func(tx *BaseTx) error {
+		for _, stmt := range stmts {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+}
@@ -0,0 +1,114 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseChangeNotify(t *testing.T) {
+	Convey("解析触发器发送的消息", t, func() {
+		events := parseChangeNotify(`{"schema":"polaris","table":"instance","op":"UPDATE","keys":["a","b"]}`,
+			"polaris")
+		So(events, ShouldResemble, []ChangeEvent{
+			{Table: "instance", Op: "UPDATE", Key: "a"}, {Table: "instance", Op: "UPDATE", Key: "b"},
+		})
+
+		// 变更的记录过多时只通知表
+		events = parseChangeNotify(`{"schema":"polaris","table":"instance","op":"DELETE","keys":null}`, "polaris")
+		So(events, ShouldResemble, []ChangeEvent{{Table: "instance", Op: "DELETE"}})
+
+		So(parseChangeNotify(`{"schema":"public","table":"instance","op":"UPDATE","keys":["a"]}`, "polaris"),
+			ShouldBeEmpty)
+		So(parseChangeNotify(`not json`, "polaris"), ShouldBeEmpty)
+	})
+}
+
+func TestChangeTriggerSql(t *testing.T) {
+	Convey("每种操作一个语句级触发器", t, func() {
+		So(changeTriggerSql("user", "update"), ShouldEqual, `CREATE TRIGGER "user_notify_update" `+
+			`AFTER UPDATE ON "user" REFERENCING OLD TABLE AS polaris_old NEW TABLE AS polaris_new `+
+			`FOR EACH STATEMENT EXECUTE FUNCTION polaris_notify_change_stmt('id')`)
+		So(changeTriggerSql("namespace", "insert"), ShouldEqual, `CREATE TRIGGER "namespace_notify_insert" `+
+			`AFTER INSERT ON "namespace" REFERENCING NEW TABLE AS polaris_new `+
+			`FOR EACH STATEMENT EXECUTE FUNCTION polaris_notify_change_stmt('name')`)
+	})
+}
+
+func TestChangeFeedSubscribe(t *testing.T) {
+	Convey("按表过滤订阅的事件", t, func() {
+		feed := newChangeFeed("", 4)
+		instances, cancelInstances := feed.subscribe("instance")
+		all, cancelAll := feed.subscribe()
+		defer cancelAll()
+
+		feed.publish(ChangeEvent{Table: "service", Op: "INSERT", Key: "s1"})
+		feed.publish(ChangeEvent{Table: "instance", Op: "DELETE", Key: "i1"})
+		feed.publish(ChangeEvent{Resync: true})
+
+		So(<-instances, ShouldResemble, ChangeEvent{Table: "instance", Op: "DELETE", Key: "i1"})
+		So((<-instances).Resync, ShouldBeTrue)
+		So(len(all), ShouldEqual, 3)
+
+		cancelInstances()
+		cancelInstances()
+		_, ok := <-instances
+		So(ok, ShouldBeFalse)
+		feed.publish(ChangeEvent{Table: "instance", Op: "UPDATE", Key: "i2"})
+		So(len(all), ShouldEqual, 4)
+	})
+	Convey("消费过慢丢弃事件后补发 Resync 事件", t, func() {
+		feed := newChangeFeed("", 2)
+		ch, cancel := feed.subscribe()
+		defer cancel()
+
+		feed.publish(ChangeEvent{Table: "instance", Key: "i1"})
+		feed.publish(ChangeEvent{Table: "instance", Key: "i2"})
+		feed.publish(ChangeEvent{Table: "instance", Key: "i3"})
+		So((<-ch).Key, ShouldEqual, "i1")
+		So((<-ch).Key, ShouldEqual, "i2")
+
+		feed.publish(ChangeEvent{Table: "instance", Key: "i4"})
+		So((<-ch).Resync, ShouldBeTrue)
+		So((<-ch).Key, ShouldEqual, "i4")
+	})
+}
+
+func TestParseChangeFeedConfig(t *testing.T) {
+	Convey("解析变更通知配置", t, func() {
+		cfg, err := parseChangeFeedConfig(nil)
+		So(err, ShouldBeNil)
+		So(cfg.enable, ShouldBeFalse)
+		So(cfg.bufferSize, ShouldEqual, DefaultChangeFeedBufferSize)
+
+		cfg, err = parseChangeFeedConfig(map[interface{}]interface{}{"enable": true, "bufferSize": 16})
+		So(err, ShouldBeNil)
+		So(cfg.enable, ShouldBeTrue)
+		So(cfg.bufferSize, ShouldEqual, 16)
+
+		for _, opts := range []interface{}{
+			true,
+			map[interface{}]interface{}{"enable": "true"},
+			map[interface{}]interface{}{"bufferSize": 0},
+		} {
+			_, err = parseChangeFeedConfig(opts)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
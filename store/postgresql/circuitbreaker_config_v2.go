@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strconv"
@@ -61,7 +62,7 @@ const (
 
 	queryCircuitBreakerRuleCacheSql = "select id, name, namespace, enable, revision, description, " +
 		"level, src_service, src_namespace, dst_service, dst_namespace, dst_method, config, flag, " +
-		"ctime, mtime, etime from circuitbreaker_rule_v2 where mtime > $1"
+		"ctime, mtime, etime from circuitbreaker_rule_v2 where mtime >= $1"
 )
 
 const (
@@ -77,7 +78,7 @@ const (
 // circuitBreakerStore 的实现
 type circuitBreakerStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 func (c *circuitBreakerStore) CreateCircuitBreakerRule(cbRule *model.CircuitBreakerRule) error {
@@ -266,7 +267,7 @@ func (c *circuitBreakerStore) getBriefCircuitBreakerRules(
 	str := queryCircuitBreakerRuleBriefSql + queryStr +
 		fmt.Sprintf(` order by mtime desc limit $%d offset $%d`, idx, idx+1)
 
-	rows, err := c.master.Query(str, args...)
+	rows, err := c.master.Query("circuitBreakerStore.getBriefCircuitBreakerRules", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] query brief circuitbreaker rules err: %s", err.Error())
 		return nil, err
@@ -399,7 +400,7 @@ func (c *circuitBreakerStore) getFullCircuitBreakerRules(
 	args = append(args, limit, offset)
 	str := queryCircuitBreakerRuleFullSql + queryStr + fmt.Sprintf(` order by mtime desc limit $%d offset $%d`, idx, idx+1)
 
-	rows, err := c.master.Query(str, args...)
+	rows, err := c.master.Query("circuitBreakerStore.getFullCircuitBreakerRules", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] query brief circuitbreaker rules err: %s", err.Error())
 		return nil, err
@@ -437,7 +438,7 @@ func (c *circuitBreakerStore) getCircuitBreakerRulesCount(filter map[string]stri
 	queryStr, args, _ := genCircuitBreakerRuleSQL(filter)
 	str := countCircuitBreakerRuleSql + queryStr
 	var total uint32
-	err := c.master.QueryRow(str, args...).Scan(&total)
+	err := c.master.QueryRow("circuitBreakerStore.getCircuitBreakerRulesCount", str, args...).Scan(&total)
 	switch {
 	case err == sql.ErrNoRows:
 		return 0, nil
@@ -456,7 +457,10 @@ func (c *circuitBreakerStore) GetCircuitBreakerRulesForCache(
 	if firstUpdate {
 		str += " and flag != 1"
 	}
-	rows, err := c.slave.Query(str, mtime)
+	since := watermarks.since(mtime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := c.slave.cacheReader().QueryContext(ctx, "circuitBreakerStore.GetCircuitBreakerRulesForCache", str, since)
 	if err != nil {
 		log.Errorf("[Store][database] query circuitbreaker rules with mtime err: %s", err.Error())
 		return nil, err
@@ -465,7 +469,10 @@ func (c *circuitBreakerStore) GetCircuitBreakerRulesForCache(
 	if err != nil {
 		return nil, err
 	}
-	return cbRules, nil
+	return dedupSlice(watermarks, "circuitbreaker_rule_v2", since, firstUpdate, cbRules,
+		func(rule *model.CircuitBreakerRule) (string, string) {
+			return rule.ID, rowVersion(rule.ModifyTime, rule.Revision)
+		}), nil
 }
 
 // EnableCircuitBreakerRule enable circuitbreaker rule
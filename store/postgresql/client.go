@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -31,7 +32,7 @@ import (
 
 type clientStore struct {
 	master *BaseDB
-	slave  *BaseDB // 缓存相关的读取，请求到slave
+	slave  *slavePool // 缓存相关的读取，请求到slave
 }
 
 // CreateClient insert the client info
@@ -112,7 +113,10 @@ func (cs *clientStore) GetMoreClients(mtime time.Time, firstUpdate bool) (map[st
 	if firstUpdate {
 		str += " and flag != 1"
 	}
-	rows, err := cs.slave.Query(str, mtime)
+	since := watermarks.since(mtime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := cs.slave.cacheReader().QueryContext(ctx, "clientStore.GetMoreClients", str, since)
 	if err != nil {
 		log.Errorf("[Store][database] get more client query err: %s", err.Error())
 		return nil, err
@@ -134,11 +138,20 @@ func (cs *clientStore) GetMoreClients(mtime time.Time, firstUpdate bool) (map[st
 		return nil, err
 	}
 
-	return out, nil
+	// client 的修改时间只精确到秒，版本中加上上报的内容
+	return dedupMap(watermarks, "client", since, firstUpdate, out, func(client *model.Client) string {
+		info := client.Proto()
+		revision := []string{info.GetHost().GetValue(), info.GetVersion().GetValue()}
+		for _, stat := range info.GetStat() {
+			revision = append(revision, fmt.Sprintf("%s:%d%s", stat.GetTarget().GetValue(),
+				stat.GetPort().GetValue(), stat.GetPath().GetValue()))
+		}
+		return rowVersion(client.ModifyTime(), revision...)
+	}), nil
 }
 
 func (cs *clientStore) batchAddClients(clients []*model.Client) error {
-	tx, err := cs.master.Begin()
+	tx, err := cs.master.Begin("clientStore.batchAddClients")
 	if err != nil {
 		log.Errorf("[Store][database] batch add clients tx begin err: %s", err.Error())
 		return err
@@ -182,7 +195,7 @@ func (cs *clientStore) batchAddClients(clients []*model.Client) error {
 }
 
 func (cs *clientStore) batchDeleteClients(ids []string) error {
-	tx, err := cs.master.Begin()
+	tx, err := cs.master.Begin("clientStore.batchDeleteClients")
 	if err != nil {
 		log.Errorf("[Store][database] batch delete clients tx begin err: %s", err.Error())
 		return err
@@ -215,9 +228,9 @@ func batchDeleteClientsMain(tx *BaseTx, ids []string) error {
 			return nil
 		}
 		placeholder, _ := PlaceholdersNI(len(objects), 1)
-		str := fmt.Sprintf("update client set flag = 1, mtime = %s", GetCurrentTimeFormat())
+		str := fmt.Sprintf("update client set flag = 1, mtime = '%s'", GetCurrentTimeFormat())
 		str += " where id in ( " + placeholder + ")"
-		stmt, err := tx.Prepare(str)
+		stmt, err := tx.prepareDynamic(str)
 		if err != nil {
 			return store.Error(err)
 		}
@@ -238,7 +251,7 @@ func batchCleanClientStats(tx *BaseTx, ids []string) error {
 		}
 		placeholder, _ := PlaceholdersNI(len(objects), 1)
 		str := "delete from client_stat where client_id in (" + placeholder + ")"
-		stmt, err := tx.Prepare(str)
+		stmt, err := tx.prepareDynamic(str)
 		if err != nil {
 			return store.Error(err)
 		}
@@ -249,7 +262,7 @@ func batchCleanClientStats(tx *BaseTx, ids []string) error {
 
 func (cs *clientStore) GetClientStat(clientID string) ([]*model.ClientStatStore, error) {
 	str := "select target, port, protocol, path from client_stat where client.id = $1"
-	rows, err := cs.master.Query(str, clientID)
+	rows, err := cs.master.Query("clientStore.GetClientStat", str, clientID)
 	if err != nil {
 		log.Errorf("[Store][database] query client stat err: %s", err.Error())
 		return nil, err
@@ -311,7 +324,7 @@ func callFetchClientRows(rows *sql.Rows, callback func(entry *model.ClientStore)
 }
 
 func (cs *clientStore) createClient(client *model.Client) error {
-	tx, err := cs.master.Begin()
+	tx, err := cs.master.Begin("clientStore.createClient")
 	if err != nil {
 		log.Errorf("[Store][database] create client tx begin err: %s", err.Error())
 		return err
@@ -337,7 +350,7 @@ func (cs *clientStore) createClient(client *model.Client) error {
 }
 
 func (cs *clientStore) updateClient(client *model.Client) error {
-	tx, err := cs.master.Begin()
+	tx, err := cs.master.Begin("clientStore.updateClient")
 	if err != nil {
 		log.Errorf("[Store][database] update client tx begin err: %s", err.Error())
 		return err
@@ -404,7 +417,7 @@ func batchAddClientMain(tx *BaseTx, clients []*model.Client) error {
 			client.Proto().GetLocation().GetZone().GetValue(),
 			client.Proto().GetLocation().GetCampus().GetValue())
 	}
-	stmt, err := tx.Prepare(str)
+	stmt, err := tx.prepareDynamic(str)
 	if err != nil {
 		return err
 	}
@@ -436,7 +449,7 @@ func batchAddClientStat(tx *BaseTx, client2Stats map[string][]*apiservice.StatIn
 				entry.GetPath().GetValue())
 		}
 	}
-	stmt, err := tx.Prepare(str)
+	stmt, err := tx.prepareDynamic(str)
 	if err != nil {
 		return err
 	}
@@ -467,7 +480,7 @@ func addClientStat(tx *BaseTx, client *model.Client) error {
 			entry.GetProtocol().GetValue(),
 			entry.GetPath().GetValue())
 	}
-	stmt, err := tx.Prepare(str)
+	stmt, err := tx.prepareDynamic(str)
 	if err != nil {
 		return err
 	}
@@ -24,6 +24,8 @@ import (
 	"strings"
 	"time"
 	"unicode"
+
+	"github.com/polarismesh/polaris/plugin"
 )
 
 // QueryHandler is the interface that wraps the basic Query method.
@@ -91,12 +93,12 @@ func BatchOperation(label string, data []interface{}, handler BatchHandler) erro
 	return nil
 }
 
-// queryEntryCount 单独查询count个数的执行函数
-func queryEntryCount(conn *BaseDB, str string, args []interface{}) (uint32, error) {
+// queryEntryCount 单独查询count个数的执行函数，label 为发起查询的 store 方法
+func queryEntryCount(conn *BaseDB, label string, str string, args []interface{}) (uint32, error) {
 	var count uint32
 	var err error
 	Retry("queryRow", func() error {
-		err = conn.QueryRow(str, args...).Scan(&count)
+		err = conn.QueryRow(label, str, args...).Scan(&count)
 		return err
 	})
 	switch {
@@ -134,16 +136,6 @@ func serviceAliasFilter2Where(filter map[string]string) map[string]string {
 	return out
 }
 
-// timeToTimestamp 转时间戳（秒）
-// 由于 FROM_UNIXTIME 不支持负数，所以小于0的情况赋值为 1
-func timeToTimestamp(t time.Time) int64 {
-	ts := t.Unix()
-	if ts < 0 {
-		ts = 1
-	}
-	return ts
-}
-
 func toUnderscoreName(name string) string {
 	var buf bytes.Buffer
 	for i, token := range name {
@@ -155,21 +147,95 @@ func toUnderscoreName(name string) string {
 	return buf.String()
 }
 
-func GetLocation() *time.Location {
-	loc, err := time.LoadLocation("Asia/Shanghai")
+const (
+	// DefaultTimezone 新建 schema 默认的时区
+	DefaultTimezone = "UTC"
+	// LegacyTimezone 历史版本固定使用的时区，历史版本建的表中不带时区的 timestamp 按该时区的本地时间写入
+	LegacyTimezone = "Asia/Shanghai"
+
+	// legacyTimestampSql 当前 schema 中是否存在不带时区的 timestamp 列，schema_migrations 由 store 自身创建，不计入
+	legacyTimestampSql = "SELECT EXISTS (SELECT 1 FROM pg_attribute a " +
+		"JOIN pg_class c ON c.oid = a.attrelid " +
+		"JOIN pg_namespace n ON n.oid = c.relnamespace " +
+		"WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND c.relname <> '" + migrationTable + "' " +
+		"AND a.attnum > 0 AND NOT a.attisdropped AND a.atttypid = 'timestamp'::regtype)"
+)
+
+// location 当前生效的时区，在 Initialize 时按 timezone 配置设置
+var location = time.UTC
+
+// parseTimezone 解析 timezone 配置，返回时区名称以及对应的时区，未配置时返回空，由 detectTimezone 按 schema 选择
+// 时区名称会作为连接的 TimeZone 参数发送给数据库，只允许 IANA 时区名称
+func parseTimezone(opt interface{}) (string, *time.Location, error) {
+	name, _ := opt.(string)
+	if name == "" {
+		return "", nil, nil
+	}
+	if name == "Local" {
+		return "", nil, fmt.Errorf("config Plugin %s:timezone must be an IANA time zone name", STORENAME)
+	}
+	loc, err := loadTimezone(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("config Plugin %s:timezone %s is invalid: %w", STORENAME, name, err)
+	}
+	return name, loc, nil
+}
+
+// loadTimezone 加载时区，缺少时区数据时历史版本的时区退化为固定的 UTC+8
+func loadTimezone(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil && name == LegacyTimezone {
+		return time.FixedZone("CST", 8*3600), nil
+	}
+	return loc, err
+}
+
+// defaultTimezone 未配置 timezone 时使用的时区
+// 存在历史版本建的 timestamp 列时，已有数据是按 LegacyTimezone 写入的本地时间，继续使用该时区读写，
+// 迁移 0006 转换为 timestamptz 时也按该时区解释，避免数据整体偏移
+func defaultTimezone(legacy bool) (string, *time.Location) {
+	if !legacy {
+		return DefaultTimezone, time.UTC
+	}
+	loc, _ := loadTimezone(LegacyTimezone)
+	return LegacyTimezone, loc
+}
+
+// detectTimezone 未配置 timezone 时连接主库检查 schema，选择默认的时区
+// 此时连接池的会话时区还未确定，使用单独的连接池检查，检查完成后关闭
+func detectTimezone(cfg *dbConfig, parsePwd plugin.ParsePassword) (string, *time.Location, error) {
+	probe := *cfg
+	probe.timezone = ""
+	probe.stmtCacheSize = -1
+	db, err := NewBaseDB(&probe, parsePwd)
 	if err != nil {
-		loc = time.FixedZone("CTS", 8*3600)
+		return "", nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	var legacy bool
+	if err := db.QueryRow("detectTimezone", legacyTimestampSql).Scan(&legacy); err != nil {
+		return "", nil, err
+	}
+	name, loc := defaultTimezone(legacy)
+	if legacy {
+		log.Warnf("[Store][database] timezone is not configured and schema has legacy timestamp columns, use %s", name)
 	}
-	return loc
+	return name, loc, nil
+}
+
+// GetLocation 获取配置的时区
+func GetLocation() *time.Location {
+	return location
 }
 
-// GetCurrentTimeFormat 获取格式化时间
-// @param millTime：时间毫秒
-// @return 2006-01-02 15:04:05
+// GetCurrentTimeFormat 获取格式化时间，带有时区偏移，写入 timestamptz 列时不依赖连接的时区
+// @return 2006-01-02 15:04:05.999999-07:00
 func GetCurrentTimeFormat() string {
 	loc := GetLocation()
 	currentTime := time.Now().In(loc)
-	format := currentTime.Format("2006-01-02 15:04:05")
+	format := currentTime.Format("2006-01-02 15:04:05.999999-07:00")
 	return format
 }
 
@@ -0,0 +1,73 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseTimezone(t *testing.T) {
+	Convey("未配置时由 schema 决定默认时区", t, func() {
+		name, loc, err := parseTimezone(nil)
+		So(err, ShouldBeNil)
+		So(name, ShouldBeEmpty)
+		So(loc, ShouldBeNil)
+
+		name, loc = defaultTimezone(false)
+		So(name, ShouldEqual, "UTC")
+		So(loc, ShouldEqual, time.UTC)
+	})
+	Convey("显式配置历史版本的时区", t, func() {
+		name, loc, err := parseTimezone(LegacyTimezone)
+		So(err, ShouldBeNil)
+		So(name, ShouldEqual, "Asia/Shanghai")
+		_, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone()
+		So(offset, ShouldEqual, 8*3600)
+	})
+	Convey("存在历史版本的 timestamp 列时默认使用历史版本的时区", t, func() {
+		name, loc := defaultTimezone(true)
+		So(name, ShouldEqual, LegacyTimezone)
+		_, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone()
+		So(offset, ShouldEqual, 8*3600)
+	})
+	Convey("配置 IANA 时区名称", t, func() {
+		name, loc, err := parseTimezone("UTC")
+		So(err, ShouldBeNil)
+		So(name, ShouldEqual, "UTC")
+		So(loc, ShouldEqual, time.UTC)
+	})
+	Convey("非法的时区", t, func() {
+		_, _, err := parseTimezone("Local")
+		So(err, ShouldNotBeNil)
+		_, _, err = parseTimezone("Mars/Olympus")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestGetCurrentTimeFormat(t *testing.T) {
+	Convey("格式化的时间带有时区偏移", t, func() {
+		before := time.Now().Truncate(time.Microsecond)
+		parsed, err := time.Parse("2006-01-02 15:04:05.999999-07:00", GetCurrentTimeFormat())
+		So(err, ShouldBeNil)
+		So(parsed.Before(before), ShouldBeFalse)
+		So(time.Since(parsed), ShouldBeLessThan, time.Minute)
+	})
+}
@@ -26,7 +26,6 @@ import (
 	"go.uber.org/zap"
 	"strconv"
 	"strings"
-	"time"
 )
 
 var (
@@ -49,7 +48,7 @@ var _ store.ConfigFileStore = (*configFileStore)(nil)
 
 type configFileStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // LockConfigFile 加锁配置文件
@@ -181,7 +180,7 @@ func (cf *configFileStore) loadFileTags(tx *BaseTx, file *model.ConfigFile) erro
 
 func (cf *configFileStore) CountConfigFiles(namespace, group string) (uint64, error) {
 	metricsSql := `SELECT count(*) FROM config_file WHERE flag = 0 AND namespace = $1 AND "group" = $2`
-	row := cf.slave.QueryRow(metricsSql, namespace, group)
+	row := cf.slave.QueryRow("configFileStore.CountConfigFiles", metricsSql, namespace, group)
 	var total uint64
 	if err := row.Scan(&total); err != nil {
 		return 0, store.Error(err)
@@ -191,7 +190,7 @@ func (cf *configFileStore) CountConfigFiles(namespace, group string) (uint64, er
 
 // GetConfigFile 获取配置文件
 func (cf *configFileStore) GetConfigFile(namespace, group, name string) (*model.ConfigFile, error) {
-	tx, err := cf.master.Begin()
+	tx, err := cf.master.Begin("configFileStore.GetConfigFile")
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -330,7 +329,7 @@ func (cf *configFileStore) QueryConfigFiles(filter map[string]string, offset, li
 	countSql += strings.Join(searchQuery, " AND ")
 
 	var count uint32
-	err := cf.master.QueryRow(countSql, args...).Scan(&count)
+	err := cf.master.QueryRow("configFileStore.QueryConfigFiles", countSql, args...).Scan(&count)
 	if err != nil {
 		log.Error("[Config][Storage] query config files", zap.String("count-sql", countSql), zap.Error(err))
 		return 0, nil, store.Error(err)
@@ -340,7 +339,7 @@ func (cf *configFileStore) QueryConfigFiles(filter map[string]string, offset, li
 	querySql += strings.Join(searchQuery, " AND ") + " ORDER BY id DESC LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
 
 	args = append(args, limit, offset)
-	rows, err := cf.master.Query(querySql, args...)
+	rows, err := cf.master.Query("configFileStore.QueryConfigFiles", querySql, args...)
 	if err != nil {
 		log.Error("[Config][Storage] query config files", zap.String("query-sql", countSql), zap.Error(err))
 		return 0, nil, store.Error(err)
@@ -373,7 +372,7 @@ func (cf *configFileStore) CountConfigFileEachGroup() (map[string]map[string]int
 		WHERE flag = 0 
 		GROUP BY namespace, "group"`
 
-	rows, err := cf.slave.Query(metricsSql)
+	rows, err := cf.slave.Query("configFileStore.CountConfigFileEachGroup", metricsSql)
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -415,7 +414,7 @@ func (cf *configFileStore) baseSelectConfigFileSql() string {
 
 func (cf *configFileStore) hardDeleteConfigFile(namespace, group, name string) error {
 	deleteSql := `DELETE FROM config_file WHERE namespace = $1 AND "group" = $2 AND name = $3 AND flag = 1`
-	_, err := cf.master.Exec(deleteSql, namespace, group, name)
+	_, err := cf.master.Exec("configFileStore.hardDeleteConfigFile", deleteSql, namespace, group, name)
 	if err != nil {
 		return store.Error(err)
 	}
@@ -454,8 +453,8 @@ func (cf *configFileStore) transferRows(rows *sql.Rows) ([]*model.ConfigFile, er
 			return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 		}
 
-		file.CreateTime = time.Unix(int64(ctimeFloat), 0)
-		file.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+		file.CreateTime = epochToTime(ctimeFloat)
+		file.ModifyTime = epochToTime(mtimeFloat)
 
 		files = append(files, file)
 	}
@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -30,7 +31,7 @@ import (
 
 type configFileGroupStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateConfigFileGroup 创建配置文件组
@@ -85,7 +86,7 @@ func (fg *configFileGroupStore) UpdateConfigFileGroup(fileGroup *model.ConfigFil
 		fileGroup.Name,
 	}
 
-	if _, err := fg.master.Exec(updateSql, args...); err != nil {
+	if _, err := fg.master.Exec("configFileGroupStore.UpdateConfigFileGroup", updateSql, args...); err != nil {
 		return store.Error(err)
 	}
 	return nil
@@ -94,7 +95,7 @@ func (fg *configFileGroupStore) UpdateConfigFileGroup(fileGroup *model.ConfigFil
 // GetConfigFileGroup 获取配置文件组
 func (fg *configFileGroupStore) GetConfigFileGroup(namespace, name string) (*model.ConfigFileGroup, error) {
 	querySql := fg.genConfigFileGroupSelectSql() + " WHERE namespace=$1 AND name=$2 AND flag = 0"
-	rows, err := fg.master.Query(querySql, namespace, name)
+	rows, err := fg.master.Query("configFileGroupStore.GetConfigFileGroup", querySql, namespace, name)
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -113,7 +114,7 @@ func (fg *configFileGroupStore) DeleteConfigFileGroup(namespace, name string) er
 	deleteSql := "UPDATE config_file_group SET flag = 1 WHERE namespace = $1 and name = $2"
 
 	log.Infof("[Config][Storage] delete config file group(%s, %s)", namespace, name)
-	if _, err := fg.master.Exec(deleteSql, namespace, name); err != nil {
+	if _, err := fg.master.Exec("configFileGroupStore.DeleteConfigFileGroup", deleteSql, namespace, name); err != nil {
 		return err
 	}
 
@@ -143,16 +144,26 @@ FROM config_file_group
 WHERE modify_time >= $1
 `
 
-	rows, err := fg.slave.Query(loadSql, mtime)
+	since := watermarks.since(mtime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := fg.slave.cacheReader().QueryContext(ctx, "configFileGroupStore.GetMoreConfigGroup", loadSql, since)
 	if err != nil {
 		return nil, err
 	}
-	return fg.transferRows(rows)
+	groups, err := fg.transferRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	return dedupSlice(watermarks, "config_file_group", since, firstUpdate, groups,
+		func(group *model.ConfigFileGroup) (string, string) {
+			return strconv.FormatUint(group.Id, 10), rowVersion(group.ModifyTime)
+		}), nil
 }
 
 func (fg *configFileGroupStore) CountConfigGroups(namespace string) (uint64, error) {
 	metricsSql := "SELECT count(*) FROM config_file_group WHERE flag = 0 AND namespace = $1"
-	row := fg.master.QueryRow(metricsSql, namespace)
+	row := fg.master.QueryRow("configFileGroupStore.CountConfigGroups", metricsSql, namespace)
 	var total uint64
 	if err := row.Scan(&total); err != nil {
 		return 0, store.Error(err)
@@ -226,8 +237,8 @@ func (fg *configFileGroupStore) transferRows(rows *sql.Rows) ([]*model.ConfigFil
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 		}
-		fileGroup.CreateTime = time.Unix(int64(ctimeFloat), 0)
-		fileGroup.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+		fileGroup.CreateTime = epochToTime(ctimeFloat)
+		fileGroup.ModifyTime = epochToTime(mtimeFloat)
 
 		// 处理 Metadata
 		fileGroup.Metadata = make(map[string]string)
@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -38,7 +39,7 @@ var (
 
 type configFileReleaseStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateConfigFileReleaseTx 新建配置文件发布
@@ -109,7 +110,7 @@ func (cfr *configFileReleaseStore) CreateConfigFileReleaseTx(tx store.Tx, data *
 
 // GetConfigFileRelease 获取配置文件发布，只返回 flag=0 的记录
 func (cfr *configFileReleaseStore) GetConfigFileRelease(req *model.ConfigFileReleaseKey) (*model.ConfigFileRelease, error) {
-	tx, err := cfr.master.Begin()
+	tx, err := cfr.master.Begin("configFileReleaseStore.GetConfigFileRelease")
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -227,12 +228,12 @@ func (cfr *configFileReleaseStore) CleanDeletedConfigFileRelease(endTime time.Ti
 			WHERE modify_time < $1 AND flag = 1 
 			LIMIT $2
 		)`
-	_, err := cfr.master.Exec(delSql, endTime, limit)
+	_, err := cfr.master.Exec("configFileReleaseStore.CleanDeletedConfigFileRelease", delSql, endTime, limit)
 	return err
 }
 
 func (cfr *configFileReleaseStore) GetConfigFileActiveRelease(file *model.ConfigFileKey) (*model.ConfigFileRelease, error) {
-	tx, err := cfr.master.Begin()
+	tx, err := cfr.master.Begin("configFileReleaseStore.GetConfigFileActiveRelease")
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -436,8 +437,12 @@ func (cfr *configFileReleaseStore) GetMoreReleaseFile(firstUpdate bool, modifyTi
 	}
 
 	// 使用 PostgreSQL 的时间比较
-	s := cfr.baseQuerySql() + " WHERE modify_time > $1"
-	rows, err := cfr.slave.Query(s, modifyTime) // 直接传入 time.Time 类型
+	s := cfr.baseQuerySql() + " WHERE modify_time >= $1"
+	since := watermarks.since(modifyTime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	// 直接传入 time.Time 类型
+	rows, err := cfr.slave.cacheReader().QueryContext(ctx, "configFileReleaseStore.GetMoreReleaseFile", s, since)
 	if err != nil {
 		return nil, err
 	}
@@ -446,7 +451,11 @@ func (cfr *configFileReleaseStore) GetMoreReleaseFile(firstUpdate bool, modifyTi
 	if err != nil {
 		return nil, err
 	}
-	return releases, nil
+	return dedupSlice(watermarks, "config_file_release", since, firstUpdate, releases,
+		func(release *model.ConfigFileRelease) (string, string) {
+			return strconv.FormatUint(release.Id, 10),
+				rowVersion(release.ModifyTime, strconv.FormatUint(release.Version, 10))
+		}), nil
 }
 
 // CountConfigReleases 获取一个配置文件组下的文件数量
@@ -460,7 +469,7 @@ func (cfr *configFileReleaseStore) CountConfigReleases(namespace, group string,
 	}
 
 	// 执行查询
-	row := cfr.master.QueryRow(metricsSql, namespace, group)
+	row := cfr.master.QueryRow("configFileReleaseStore.CountConfigReleases", metricsSql, namespace, group)
 	var total uint64
 	if err := row.Scan(&total); err != nil {
 		return 0, store.Error(err)
@@ -510,8 +519,8 @@ func (cfr *configFileReleaseStore) transferRows(rows *sql.Rows) ([]*model.Config
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 		}
-		fileRelease.CreateTime = time.Unix(int64(ctimeFloat), 0)
-		fileRelease.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+		fileRelease.CreateTime = epochToTime(ctimeFloat)
+		fileRelease.ModifyTime = epochToTime(mtimeFloat)
 		fileRelease.Active = active == 1           // 转换 active 字段
 		fileRelease.Valid = fileRelease.Flag == 0  // 检查有效性
 		fileRelease.Metadata = map[string]string{} // 初始化 Metadata
@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -31,7 +32,7 @@ import (
 
 type configFileReleaseHistoryStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateConfigFileReleaseHistory 创建配置文件发布历史记录
@@ -89,14 +90,15 @@ func (rh *configFileReleaseHistoryStore) QueryConfigFileReleaseHistories(filter
 	queryParams = append(queryParams, "%"+fileName+"%")
 
 	var count uint32
-	err := rh.master.QueryRow(countSql, queryParams...).Scan(&count)
+	err := rh.master.QueryRow("configFileReleaseHistoryStore.QueryConfigFileReleaseHistories", countSql,
+		queryParams...).Scan(&count)
 	if err != nil {
 		return 0, nil, err
 	}
 
 	queryParams = append(queryParams, limit)
 	queryParams = append(queryParams, offset)
-	rows, err := rh.master.Query(querySql, queryParams...)
+	rows, err := rh.master.Query("configFileReleaseHistoryStore.QueryConfigFileReleaseHistories", querySql, queryParams...)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -110,15 +112,25 @@ func (rh *configFileReleaseHistoryStore) QueryConfigFileReleaseHistories(filter
 }
 
 // CleanConfigFileReleaseHistory 清理配置发布历史
+// 分区表先整体删除早于 endTime 的分区，跨越 endTime 的分区中剩余的过期记录再按 limit 分批删除
 func (rh *configFileReleaseHistoryStore) CleanConfigFileReleaseHistory(endTime time.Time, limit uint64) error {
-	delSql := "DELETE FROM config_file_release_history WHERE create_time < $1 LIMIT $2"
-	_, err := rh.master.Exec(delSql, endTime, limit)
+	ctx, cancel := newOpContext(context.Background(), opAdminCleanup)
+	defer cancel()
+	// 分区边界为会话时区的墙上时间
+	wall := wallClock(endTime.In(GetLocation()))
+	if _, err := rh.master.dropPartitionsBefore(ctx, "config_file_release_history", wall); err != nil {
+		return err
+	}
+	delSql := "DELETE FROM config_file_release_history WHERE (id, create_time) IN " +
+		"(SELECT id, create_time FROM config_file_release_history WHERE create_time < $1 LIMIT $2)"
+	_, err := rh.master.ExecContext(ctx, "configFileReleaseHistoryStore.CleanConfigFileReleaseHistory",
+		delSql, wall, limit)
 	return err
 }
 
 func (rh *configFileReleaseHistoryStore) genSelectSql() string {
 	return "select id, name, namespace, \"group\", file_name, content, comment, md5, format, tags, type, " +
-		" status, create_time, create_by, modify_time, " +
+		" status, EXTRACT(EPOCH FROM create_time::timestamptz), create_by, EXTRACT(EPOCH FROM modify_time), " +
 		"modify_by, reason, description, version from config_file_release_history "
 }
 
@@ -153,8 +165,8 @@ func (rh *configFileReleaseHistoryStore) transferRows(rows *sql.Rows) ([]*model.
 			return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 		}
 
-		item.CreateTime = time.Unix(int64(ctimeFloat), 0)
-		item.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+		item.CreateTime = epochToTime(ctimeFloat)
+		item.ModifyTime = epochToTime(mtimeFloat)
 		item.Metadata = map[string]string{}
 		_ = json.Unmarshal([]byte(tags), &item.Metadata)
 
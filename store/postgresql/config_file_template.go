@@ -26,7 +26,7 @@ import (
 
 type configFileTemplateStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateConfigFileTemplate create config file template
@@ -50,7 +50,7 @@ func (cf *configFileTemplateStore) CreateConfigFileTemplate(
 // GetConfigFileTemplate get config file template by name
 func (cf *configFileTemplateStore) GetConfigFileTemplate(name string) (*model.ConfigFileTemplate, error) {
 	querySql := cf.baseSelectConfigFileTemplateSql() + " where name = $1"
-	rows, err := cf.master.Query(querySql, name)
+	rows, err := cf.master.Query("configFileTemplateStore.GetConfigFileTemplate", querySql, name)
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -68,7 +68,7 @@ func (cf *configFileTemplateStore) GetConfigFileTemplate(name string) (*model.Co
 // QueryAllConfigFileTemplates query all config file templates
 func (cf *configFileTemplateStore) QueryAllConfigFileTemplates() ([]*model.ConfigFileTemplate, error) {
 	querySql := cf.baseSelectConfigFileTemplateSql() + " order by id desc"
-	rows, err := cf.master.Query(querySql)
+	rows, err := cf.master.Query("configFileTemplateStore.QueryAllConfigFileTemplates", querySql)
 	if err != nil {
 		return nil, store.Error(err)
 	}
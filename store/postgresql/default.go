@@ -18,8 +18,11 @@
 package postgresql
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/polarismesh/polaris/plugin"
 	"github.com/polarismesh/polaris/store"
@@ -73,9 +76,19 @@ type PostgresqlStore struct {
 
 	// 主数据库，可以进行读写
 	master *BaseDB
-	// 备数据库，提供只读
-	slave *BaseDB
+	// 备数据库，提供只读，可以包含多个只读实例
+	slave *slavePool
 	start bool
+	// cancel 停止 store 的后台任务
+	cancel context.CancelFunc
+	// changes 数据变更通知，未开启时为空
+	changes *changeFeed
+	// cdc 逻辑复制消费方，未开启时为空
+	cdc *cdcConsumer
+	// purge 软删除数据的清理配置
+	purge *purgeConfig
+	// leaderElection 选举后端，table 或 advisoryLock
+	leaderElection string
 }
 
 // Name 实现Name函数
@@ -89,27 +102,155 @@ func (p *PostgresqlStore) Initialize(conf *store.Config) error {
 		return nil
 	}
 
-	masterConfig, slaveConfig, err := parseDatabaseConf(conf.Option)
+	masterConfig, slaveConfigs, err := parseDatabaseConf(conf.Option)
 	if err != nil {
 		return err
 	}
+	timezone, loc, err := parseTimezone(conf.Option["timezone"])
+	if err != nil {
+		return err
+	}
+	if timezone == "" {
+		if timezone, loc, err = detectTimezone(masterConfig, plugin.GetParsePassword()); err != nil {
+			return err
+		}
+	}
+	location = loc
+	masterConfig.timezone = timezone
+	for _, slaveConfig := range slaveConfigs {
+		slaveConfig.timezone = timezone
+	}
+	if retryCfg, err = parseRetryConfig(conf.Option["retry"]); err != nil {
+		return err
+	}
+	if opTimeouts, err = parseTimeoutConfig(conf.Option["timeout"]); err != nil {
+		return err
+	}
+	slowQueryCfg, err := parseSlowQueryConfig(conf.Option["slowQuery"])
+	if err != nil {
+		return err
+	}
+	slowQueries = newSlowQueryLog(slowQueryCfg)
+	schemaCheckMode, err := parseSchemaCheckMode(conf.Option["schemaCheck"])
+	if err != nil {
+		return err
+	}
+	partitionCfg, err := parsePartitionConfig(conf.Option["partition"])
+	if err != nil {
+		return err
+	}
+	changeFeedCfg, err := parseChangeFeedConfig(conf.Option["changeFeed"])
+	if err != nil {
+		return err
+	}
+	cdcCfg, err := parseCDCConfig(conf.Option["cdc"])
+	if err != nil {
+		return err
+	}
+	purgeCfg, err := parsePurgeConfig(conf.Option["purge"])
+	if err != nil {
+		return err
+	}
+	if lockWaitPolicy, err = parseLockWaitPolicy(conf.Option["rowLockWait"]); err != nil {
+		return err
+	}
+	leBackend, err := parseLeaderElectionBackend(conf.Option["leaderElection"])
+	if err != nil {
+		return err
+	}
+	overlap, err := parseWatermarkOverlap(conf.Option["watermarkOverlap"])
+	if err != nil {
+		return err
+	}
+	watermarks = newWatermarkDedup(overlap)
 	master, err := NewBaseDB(masterConfig, plugin.GetParsePassword())
 	if err != nil {
 		return err
 	}
 	p.master = master
+	if autoMigrate, _ := conf.Option["autoMigrate"].(bool); autoMigrate {
+		if err := master.migrate(context.Background()); err != nil {
+			log.Errorf("[Store][database] migrate schema err: %s", err.Error())
+			_ = master.Close()
+			return err
+		}
+	}
+	if err := verifySchema(context.Background(), master, schemaCheckMode); err != nil {
+		_ = master.Close()
+		return err
+	}
+	if bootstrap, _ := conf.Option["bootstrap"].(bool); bootstrap {
+		if err := master.bootstrap(context.Background()); err != nil {
+			log.Errorf("[Store][database] bootstrap default data err: %s", err.Error())
+			_ = master.Close()
+			return err
+		}
+	}
+	if err := master.syncChangeTriggers(context.Background(), changeFeedCfg.enable); err != nil {
+		log.Errorf("[Store][database] sync change feed triggers err: %s", err.Error())
+		if changeFeedCfg.enable {
+			_ = master.Close()
+			return err
+		}
+	}
+	if cdcCfg.enable {
+		// 复制槽在 ConsumeCDC 时才创建，只有实际消费的节点会让主库保留 WAL
+		p.cdc = newCDCConsumer(master, cdcCfg)
+	}
+	// 启动时先补齐分区，失败时不影响启动，由后台任务继续重试
+	if err := master.maintainPartitions(context.Background(), partitionCfg.tables); err != nil {
+		log.Errorf("[Store][database] maintain partitions err: %s", err.Error())
+	}
 
-	if slaveConfig != nil {
-		log.Infof("[Store][database] use slave database config: %+v", slaveConfig)
+	// 如果没有配置slave，所有只读请求由master数据库承担
+	nodes := make([]*slaveNode, 0, len(slaveConfigs))
+	for _, slaveConfig := range slaveConfigs {
+		log.Infof("[Store][database] use slave database config: %s:%s, weight: %d",
+			slaveConfig.dbAddr, slaveConfig.dbPort, slaveConfig.weight)
 		slave, err := NewBaseDB(slaveConfig, plugin.GetParsePassword())
 		if err != nil {
+			for _, node := range nodes {
+				_ = node.Close()
+			}
+			_ = master.Close()
 			return err
 		}
-		p.slave = slave
+		nodes = append(nodes, &slaveNode{BaseDB: slave, weight: slaveConfig.weight})
+	}
+	checkInterval := DefaultSlaveCheckInterval
+	if interval, _ := conf.Option["slaveCheckInterval"].(int); interval > 0 {
+		checkInterval = interval
+	}
+	maxLag := 0
+	if lag, _ := conf.Option["slaveMaxLag"].(int); lag > 0 {
+		maxLag = lag
+	}
+	p.slave = newSlavePool(master, nodes, time.Duration(checkInterval)*time.Second,
+		time.Duration(maxLag)*time.Second)
+	p.slave.start()
+
+	firstLoadChunkSize = DefaultFirstLoadChunkSize
+	if size, _ := conf.Option["firstLoadChunkSize"].(int); size > 0 {
+		firstLoadChunkSize = size
+	}
+
+	statsInterval := DefaultDBStatsInterval
+	if interval, _ := conf.Option["dbStatsInterval"].(int); interval > 0 {
+		statsInterval = interval
 	}
-	// 如果slave为空，意味着slaveConfig为空，用master数据库替代
-	if p.slave == nil {
-		p.slave = p.master
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go runDBStatsReporter(ctx, time.Duration(statsInterval)*time.Second, master, p.slave)
+	go runPartitionMaintainer(ctx, master, partitionCfg)
+	p.purge = purgeCfg
+	p.leaderElection = leBackend
+	if purgeCfg.enable {
+		go runPurger(ctx, master, purgeCfg)
+	}
+	if changeFeedCfg.enable {
+		// 只读实例无法 LISTEN，变更通知由主库的独立连接接收
+		p.changes = newChangeFeed(buildDSN(master.cfg), changeFeedCfg.bufferSize)
+		go p.changes.run(ctx)
 	}
 
 	log.Infof("[Store][database] connect the database successfully")
@@ -122,7 +263,7 @@ func (p *PostgresqlStore) Initialize(conf *store.Config) error {
 }
 
 // parseDatabaseConf 解析数据库配置
-func parseDatabaseConf(opt map[string]interface{}) (*dbConfig, *dbConfig, error) {
+func parseDatabaseConf(opt map[string]interface{}) (*dbConfig, []*dbConfig, error) {
 	// 必填
 	masterEnter, ok := opt["master"]
 	if !ok || masterEnter == nil {
@@ -133,17 +274,29 @@ func parseDatabaseConf(opt map[string]interface{}) (*dbConfig, *dbConfig, error)
 		return nil, nil, err
 	}
 
-	// 只读数据库可选
+	// 只读数据库可选，支持单个配置或者多个只读实例的列表
 	slaveEntry, ok := opt["slave"]
 	if !ok || slaveEntry == nil {
 		return masterConfig, nil, nil
 	}
-	slaveConfig, err := parseStoreConfig(slaveEntry)
-	if err != nil {
-		return nil, nil, err
+	slaveEntries, ok := slaveEntry.([]interface{})
+	if !ok {
+		slaveEntries = []interface{}{slaveEntry}
+	}
+	slaveConfigs := make([]*dbConfig, 0, len(slaveEntries))
+	for _, entry := range slaveEntries {
+		slaveConfig, err := parseStoreConfig(entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		slaveConfig.weight = DefaultSlaveWeight
+		if weight, _ := entry.(map[interface{}]interface{})["weight"].(int); weight > 0 {
+			slaveConfig.weight = weight
+		}
+		slaveConfigs = append(slaveConfigs, slaveConfig)
 	}
 
-	return masterConfig, slaveConfig, nil
+	return masterConfig, slaveConfigs, nil
 }
 
 // parseStoreConfig 解析store的配置
@@ -169,24 +322,132 @@ func parseStoreConfig(opts interface{}) (*dbConfig, error) {
 		dbPort: needCheckFields["dbPort"],
 		dbName: needCheckFields["dbName"],
 	}
+	if _, ok := supportedDrivers[c.dbType]; !ok {
+		return nil, fmt.Errorf("config Plugin %s:dbType %s is not supported, must be %s or %s",
+			STORENAME, c.dbType, DriverPQ, DriverPgx)
+	}
 	if maxOpenConns, _ := obj["maxOpenConns"].(int); maxOpenConns > 0 {
 		c.maxOpenConns = maxOpenConns
 	}
 	if maxIdleConns, _ := obj["maxIdleConns"].(int); maxIdleConns > 0 {
 		c.maxIdleConns = maxIdleConns
 	}
+	// 为0时使用默认容量，小于0时关闭预编译语句缓存
+	if stmtCacheSize, ok := obj["stmtCacheSize"].(int); ok {
+		c.stmtCacheSize = stmtCacheSize
+	}
 	c.connMaxLifetime = DefaultConnMaxLifetime
 	if connMaxLifetime, _ := obj["connMaxLifetime"].(int); connMaxLifetime > 0 {
 		c.connMaxLifetime = connMaxLifetime
 	}
+	isolationLevel, err := parseIsolationLevel(obj["txIsolationLevel"])
+	if err != nil {
+		return nil, fmt.Errorf("config Plugin %s:txIsolationLevel %w", STORENAME, err)
+	}
+	c.txIsolationLevel = int(isolationLevel)
+	if err := parseConnOptions(obj, c); err != nil {
+		return nil, err
+	}
 
 	return c, nil
 }
 
+// parseConnOptions 解析 TLS 以及 libpq 的其他连接参数
+func parseConnOptions(obj map[interface{}]interface{}, c *dbConfig) error {
+	c.sslMode = DefaultSSLMode
+	if sslMode, _ := obj["sslMode"].(string); sslMode != "" {
+		if _, ok := validSSLModes[sslMode]; !ok {
+			return fmt.Errorf("config Plugin %s:sslMode %s is invalid", STORENAME, sslMode)
+		}
+		c.sslMode = sslMode
+	}
+	c.sslRootCert, _ = obj["sslRootCert"].(string)
+	c.sslCert, _ = obj["sslCert"].(string)
+	c.sslKey, _ = obj["sslKey"].(string)
+	if (c.sslCert == "") != (c.sslKey == "") {
+		return fmt.Errorf("config Plugin %s:sslCert and sslKey must be set together", STORENAME)
+	}
+	for key, file := range map[string]string{
+		"sslRootCert": c.sslRootCert, "sslCert": c.sslCert, "sslKey": c.sslKey} {
+		if file == "" {
+			continue
+		}
+		if err := checkFileReadable(file); err != nil {
+			return fmt.Errorf("config Plugin %s:%s %w", STORENAME, key, err)
+		}
+	}
+	c.applicationName, _ = obj["applicationName"].(string)
+	if val, ok := obj["dbSchema"]; ok {
+		schema, _ := val.(string)
+		if schema == "" {
+			return fmt.Errorf("config Plugin %s:dbSchema must be non-empty string", STORENAME)
+		}
+		c.dbSchema = schema
+	}
+	if connectTimeout, _ := obj["connectTimeout"].(int); connectTimeout > 0 {
+		c.connectTimeout = connectTimeout
+	}
+
+	extra, err := toStringMap(obj["extraParams"])
+	if err != nil {
+		return fmt.Errorf("config Plugin %s:extraParams %w", STORENAME, err)
+	}
+	for key := range extra {
+		if _, ok := reservedDSNKeys[key]; ok {
+			return fmt.Errorf("config Plugin %s:extraParams can not override %s", STORENAME, key)
+		}
+	}
+	c.extraParams = extra
+	return nil
+}
+
+// checkFileReadable 校验证书文件存在、为普通文件且可读
+func checkFileReadable(file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("file %s is not readable: %w", file, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("file %s is not a regular file", file)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("file %s is not readable: %w", file, err)
+	}
+	_ = f.Close()
+	return nil
+}
+
+// toStringMap 将 yaml 解析出来的 map 转为 map[string]string
+func toStringMap(val interface{}) (map[string]string, error) {
+	out := map[string]string{}
+	switch m := val.(type) {
+	case nil:
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			out[fmt.Sprintf("%v", k)] = fmt.Sprintf("%v", v)
+		}
+	case map[string]interface{}:
+		for k, v := range m {
+			out[k] = fmt.Sprintf("%v", v)
+		}
+	case map[string]string:
+		for k, v := range m {
+			out[k] = v
+		}
+	default:
+		return nil, fmt.Errorf("type must be map")
+	}
+	return out, nil
+}
+
 // Destroy 退出函数
 func (p *PostgresqlStore) Destroy() error {
 	p.start = false
 
+	if p.cancel != nil {
+		p.cancel()
+	}
 	if p.master != nil {
 		_ = p.master.Close()
 	}
@@ -200,17 +461,51 @@ func (p *PostgresqlStore) Destroy() error {
 
 	p.master = nil
 	p.slave = nil
+	p.changes = nil
+	p.cdc = nil
 
 	return nil
 }
 
+// DumpSlowQueries 按最大耗时从大到小返回最慢的语句指纹，未开启慢查询日志时返回空
+func (p *PostgresqlStore) DumpSlowQueries() []SlowQueryStat {
+	return slowQueries.dump()
+}
+
+// SubscribeChanges 订阅指定表的数据变更，tables 为空时订阅所有表，返回的函数用于取消订阅
+// 收到 Resync 事件时期间的变更可能丢失，订阅方需要按 mtime 增量拉取一次
+func (p *PostgresqlStore) SubscribeChanges(tables ...string) (<-chan ChangeEvent, func(), error) {
+	if p.changes == nil {
+		return nil, nil, errors.New("change feed is not enabled")
+	}
+	ch, cancel := p.changes.subscribe(tables...)
+	return ch, cancel, nil
+}
+
+// ConsumeCDC 从已确认的位置开始消费逻辑复制的变更，阻塞直到 ctx 结束，同一个复制槽同一时间只能有一个消费方
+// handler 返回成功后事务的位置才会被持久化，返回错误时重连并从该事务重新开始
+func (p *PostgresqlStore) ConsumeCDC(ctx context.Context, handler CDCHandler) error {
+	if p.cdc == nil {
+		return errors.New("cdc is not enabled")
+	}
+	return p.cdc.run(ctx, handler)
+}
+
+// PurgeSoftDeleted 按 purge 配置立即清理一轮过期的软删除数据，未开启定时清理时也可以调用
+func (p *PostgresqlStore) PurgeSoftDeleted(ctx context.Context) {
+	if p.master == nil || p.purge == nil {
+		return
+	}
+	p.master.purgeAll(ctx, p.purge)
+}
+
 // CreateTransaction 创建一个事务
 func (p *PostgresqlStore) CreateTransaction() (store.Transaction, error) {
 	// 每次创建事务前，还是需要ping一下
 	_ = p.master.Ping()
 
-	nt := &transaction{}
-	tx, err := p.master.Begin()
+	nt := &transaction{waitPolicy: lockWaitPolicy}
+	tx, err := p.master.Begin("PostgresqlStore.CreateTransaction")
 	if err != nil {
 		log.Errorf("[Store][database] database begin err: %s", err.Error())
 		return nil, err
@@ -222,15 +517,16 @@ func (p *PostgresqlStore) CreateTransaction() (store.Transaction, error) {
 }
 
 func (p *PostgresqlStore) StartTx() (store.Tx, error) {
-	tx, err := p.master.Begin()
+	tx, err := p.master.Begin("PostgresqlStore.StartTx")
 	if err != nil {
 		return nil, err
 	}
 	return NewSqlDBTx(tx), nil
 }
 
+// StartReadTx 开启只读事务，主要用于 cache 的增量读取
 func (p *PostgresqlStore) StartReadTx() (store.Tx, error) {
-	tx, err := p.slave.Begin()
+	tx, err := p.slave.cacheReader().beginWithClass(context.Background(), opCacheLoad, "PostgresqlStore.StartReadTx")
 	if err != nil {
 		return nil, err
 	}
@@ -258,7 +554,7 @@ func (p *PostgresqlStore) newStore() {
 	p.configFileTemplateStore = &configFileTemplateStore{master: p.master}
 	p.clientStore = &clientStore{master: p.master, slave: p.slave}
 
-	p.adminStore = newAdminStore(p.master)
+	p.adminStore = newAdminStore(p.master, p.leaderElection)
 	p.toolStore = &toolStore{db: p.master}
 	p.userStore = &userStore{master: p.master, slave: p.slave}
 	p.groupStore = &groupStore{master: p.master, slave: p.slave}
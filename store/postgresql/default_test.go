@@ -19,19 +19,30 @@ package postgresql
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/polarismesh/polaris/common/model"
 	"github.com/polarismesh/polaris/store"
+	. "github.com/smartystreets/goconvey/convey"
 )
 
+// testDBType 测试使用的驱动，可以通过环境变量 POLARIS_TEST_DB_TYPE 切换为 pgx
+func testDBType() string {
+	if dbType := os.Getenv("POLARIS_TEST_DB_TYPE"); dbType != "" {
+		return dbType
+	}
+	return DriverPQ
+}
+
 func initConf() *PostgresqlStore {
 	conf := &store.Config{
 		Name: "Postgresql",
 		Option: map[string]interface{}{
 			"master": map[interface{}]interface{}{
-				"dbType": "postgres",
+				"dbType": testDBType(),
 				"dbUser": "postgres",
 				"dbPwd":  "aaaaaa",
 				"dbAddr": "127.0.0.1",
@@ -136,3 +147,99 @@ func TestGetMoreNamespaces(t *testing.T) {
 	}
 
 }
+
+func newTestStoreOption() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"dbType": testDBType(),
+		"dbUser": "postgres",
+		"dbPwd":  "aaaaaa",
+		"dbAddr": "127.0.0.1",
+		"dbPort": "5432",
+		"dbName": "polaris_server",
+	}
+}
+
+func TestParseStoreConfig(t *testing.T) {
+	Convey("默认不开启TLS", t, func() {
+		c, err := parseStoreConfig(newTestStoreOption())
+		So(err, ShouldBeNil)
+		So(c.sslMode, ShouldEqual, DefaultSSLMode)
+		So(c.extraParams, ShouldBeEmpty)
+	})
+	Convey("解析TLS以及扩展参数", t, func() {
+		dir := t.TempDir()
+		rootCert := filepath.Join(dir, "root.crt")
+		So(os.WriteFile(rootCert, []byte("cert"), 0600), ShouldBeNil)
+
+		opt := newTestStoreOption()
+		opt["sslMode"] = "verify-full"
+		opt["sslRootCert"] = rootCert
+		opt["applicationName"] = "polaris-server"
+		opt["connectTimeout"] = 5
+		opt["extraParams"] = map[interface{}]interface{}{"target_session_attrs": "read-write"}
+		c, err := parseStoreConfig(opt)
+		So(err, ShouldBeNil)
+		So(c.sslMode, ShouldEqual, "verify-full")
+		So(c.sslRootCert, ShouldEqual, rootCert)
+		So(c.applicationName, ShouldEqual, "polaris-server")
+		So(c.connectTimeout, ShouldEqual, 5)
+		So(c.extraParams["target_session_attrs"], ShouldEqual, "read-write")
+	})
+	Convey("非法的sslMode", t, func() {
+		opt := newTestStoreOption()
+		opt["sslMode"] = "enable"
+		_, err := parseStoreConfig(opt)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("证书文件不存在", t, func() {
+		opt := newTestStoreOption()
+		opt["sslMode"] = "verify-ca"
+		opt["sslRootCert"] = filepath.Join(t.TempDir(), "not-exist.crt")
+		_, err := parseStoreConfig(opt)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("证书路径为目录", t, func() {
+		opt := newTestStoreOption()
+		opt["sslMode"] = "verify-ca"
+		opt["sslRootCert"] = t.TempDir()
+		_, err := parseStoreConfig(opt)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("sslCert与sslKey需要同时配置", t, func() {
+		dir := t.TempDir()
+		cert := filepath.Join(dir, "client.crt")
+		So(os.WriteFile(cert, []byte("cert"), 0600), ShouldBeNil)
+		opt := newTestStoreOption()
+		opt["sslCert"] = cert
+		_, err := parseStoreConfig(opt)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("extraParams不允许覆盖内置参数", t, func() {
+		opt := newTestStoreOption()
+		opt["extraParams"] = map[interface{}]interface{}{"sslmode": "disable"}
+		_, err := parseStoreConfig(opt)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("dbSchema不能为空", t, func() {
+		opt := newTestStoreOption()
+		opt["dbSchema"] = "polaris"
+		c, err := parseStoreConfig(opt)
+		So(err, ShouldBeNil)
+		So(c.dbSchema, ShouldEqual, "polaris")
+
+		opt["dbSchema"] = ""
+		_, err = parseStoreConfig(opt)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("dbType选择驱动", t, func() {
+		opt := newTestStoreOption()
+		opt["dbType"] = DriverPgx
+		c, err := parseStoreConfig(opt)
+		So(err, ShouldBeNil)
+		So(c.dbType, ShouldEqual, DriverPgx)
+
+		opt["dbType"] = "mysql"
+		_, err = parseStoreConfig(opt)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,51 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/lib/pq"
+)
+
+const (
+	// DriverPQ 使用 lib/pq 驱动
+	DriverPQ = "postgres"
+	// DriverPgx 使用 pgx 驱动的 database/sql 适配
+	DriverPgx = "pgx"
+)
+
+// supportedDrivers 支持的 dbType，即 database/sql 中注册的驱动名称
+var supportedDrivers = map[string]struct{}{
+	DriverPQ:  {},
+	DriverPgx: {},
+}
+
+// sqlState 获取数据库返回错误的 SQLSTATE，兼容 lib/pq 与 pgx，非数据库返回的错误返回空字符串
+func sqlState(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
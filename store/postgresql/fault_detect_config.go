@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -31,7 +32,7 @@ var _ store.FaultDetectRuleStore = (*faultDetectRuleStore)(nil)
 
 type faultDetectRuleStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 const (
@@ -60,7 +61,7 @@ const (
 		"dst_namespace, dst_method, ctime, mtime from fault_detect_rule where flag = 0"
 
 	queryFaultDetectCacheSql = "select id, name, namespace, revision, description, dst_service, " +
-		"dst_namespace, dst_method, config, flag, ctime, mtime from fault_detect_rule where mtime > $1"
+		"dst_namespace, dst_method, config, flag, ctime, mtime from fault_detect_rule where mtime >= $1"
 )
 
 // CreateFaultDetectRule create fault detect rule
@@ -217,7 +218,10 @@ func (f *faultDetectRuleStore) GetFaultDetectRulesForCache(
 	if firstUpdate {
 		str += " and flag != 1"
 	}
-	rows, err := f.slave.Query(str, mtime)
+	since := watermarks.since(mtime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := f.slave.cacheReader().QueryContext(ctx, "faultDetectRuleStore.GetFaultDetectRulesForCache", str, since)
 	if err != nil {
 		log.Errorf("[Store][database] query fault detect rules with mtime err: %s", err.Error())
 		return nil, err
@@ -226,7 +230,10 @@ func (f *faultDetectRuleStore) GetFaultDetectRulesForCache(
 	if err != nil {
 		return nil, err
 	}
-	return fdRules, nil
+	return dedupSlice(watermarks, "fault_detect_rule", since, firstUpdate, fdRules,
+		func(rule *model.FaultDetectRule) (string, string) {
+			return rule.ID, rowVersion(rule.ModifyTime, rule.Revision)
+		}), nil
 }
 
 func fetchFaultDetectRulesRows(rows *sql.Rows) ([]*model.FaultDetectRule, error) {
@@ -308,7 +315,7 @@ func (f *faultDetectRuleStore) getFaultDetectRulesCount(filter map[string]string
 	queryStr, args, _ := genFaultDetectRuleSQL(filter)
 	str := countFaultDetectSql + queryStr
 	var total uint32
-	err := f.master.QueryRow(str, args...).Scan(&total)
+	err := f.master.QueryRow("faultDetectRuleStore.getFaultDetectRulesCount", str, args...).Scan(&total)
 	switch {
 	case err == sql.ErrNoRows:
 		return 0, nil
@@ -326,7 +333,7 @@ func (f *faultDetectRuleStore) getBriefFaultDetectRules(
 	args = append(args, limit, offset)
 	str := queryFaultDetectBriefSql + queryStr + fmt.Sprintf(` order by mtime desc limit $%d offset $%d`, idx, idx+1)
 
-	rows, err := f.master.Query(str, args...)
+	rows, err := f.master.Query("faultDetectRuleStore.getBriefFaultDetectRules", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] query brief fault detect rule rules err: %s", err.Error())
 		return nil, err
@@ -365,7 +372,7 @@ func (f *faultDetectRuleStore) getFullFaultDetectRules(
 	args = append(args, limit, offset)
 	str := queryFaultDetectFullSql + queryStr + fmt.Sprintf(` order by mtime desc limit $%d offset $%d`, idx, idx+1)
 
-	rows, err := f.master.Query(str, args...)
+	rows, err := f.master.Query("faultDetectRuleStore.getFullFaultDetectRules", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] query brief fault detect rules err: %s", err.Error())
 		return nil, err
@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"github.com/polarismesh/polaris/common/model"
@@ -11,7 +12,7 @@ import (
 
 type grayStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateGrayResourceTx 创建灰度资源
@@ -89,12 +90,15 @@ func (g *grayStore) GetMoreGrayResouces(firstUpdate bool,
 	}
 
 	// 构造 PostgreSQL 查询
-	s := "SELECT name, match_rule, create_time, COALESCE(create_by, ''), modify_time, COALESCE(modify_by, ''), flag " +
-		"FROM gray_resource WHERE modify_time > TO_TIMESTAMP($1)"
+	s := "SELECT name, match_rule, EXTRACT(EPOCH FROM create_time), COALESCE(create_by, ''), " +
+		"EXTRACT(EPOCH FROM modify_time), COALESCE(modify_by, ''), flag FROM gray_resource WHERE modify_time >= $1"
 	if firstUpdate {
 		s += " AND flag = 0"
 	}
-	rows, err := g.slave.Query(s, timeToTimestamp(modifyTime))
+	since := watermarks.since(modifyTime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := g.slave.cacheReader().QueryContext(ctx, "grayStore.GetMoreGrayResouces", s, since)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +106,10 @@ func (g *grayStore) GetMoreGrayResouces(firstUpdate bool,
 	if err != nil {
 		return nil, err
 	}
-	return grayResources, nil
+	return dedupSlice(watermarks, "gray_resource", since, firstUpdate, grayResources,
+		func(resource *model.GrayResource) (string, string) {
+			return resource.Name, rowVersion(resource.ModifyTime)
+		}), nil
 }
 
 func (g *grayStore) fetchGrayResourceRows(rows *sql.Rows) ([]*model.GrayResource, error) {
@@ -132,8 +139,8 @@ func (g *grayStore) fetchGrayResourceRows(rows *sql.Rows) ([]*model.GrayResource
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 		}
-		grayResource.CreateTime = time.Unix(int64(ctimeFloat), 0)
-		grayResource.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+		grayResource.CreateTime = epochToTime(ctimeFloat)
+		grayResource.ModifyTime = epochToTime(mtimeFloat)
 		grayResources = append(grayResources, grayResource)
 	}
 
@@ -146,7 +153,7 @@ func (g *grayStore) fetchGrayResourceRows(rows *sql.Rows) ([]*model.GrayResource
 // DeleteGrayResource 删除灰度资源
 func (g *grayStore) DeleteGrayResource(tx store.Tx, data *model.GrayResource) error {
 	s := "DELETE FROM  gray_resource  WHERE name= $1"
-	_, err := g.master.Exec(s, data.Name)
+	_, err := g.master.Exec("grayStore.DeleteGrayResource", s, data.Name)
 	if err != nil {
 		return store.Error(err)
 	}
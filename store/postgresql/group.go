@@ -53,7 +53,7 @@ var (
 
 type groupStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // AddGroup 创建一个用户组
@@ -71,7 +71,7 @@ func (u *groupStore) AddGroup(group *model.UserGroupDetail) error {
 }
 
 func (u *groupStore) addGroup(group *model.UserGroupDetail) error {
-	tx, err := u.master.Begin()
+	tx, err := u.master.Begin("groupStore.addGroup")
 	if err != nil {
 		return err
 	}
@@ -134,7 +134,7 @@ func (u *groupStore) UpdateGroup(group *model.ModifyUserGroup) error {
 }
 
 func (u *groupStore) updateGroup(group *model.ModifyUserGroup) error {
-	tx, err := u.master.Begin()
+	tx, err := u.master.Begin("groupStore.updateGroup")
 	if err != nil {
 		return err
 	}
@@ -200,7 +200,7 @@ func (u *groupStore) DeleteGroup(group *model.UserGroupDetail) error {
 }
 
 func (u *groupStore) deleteUserGroup(group *model.UserGroupDetail) error {
-	tx, err := u.master.Begin()
+	tx, err := u.master.Begin("groupStore.deleteUserGroup")
 	if err != nil {
 		return err
 	}
@@ -250,7 +250,7 @@ func (u *groupStore) GetGroup(groupId string) (*model.UserGroupDetail, error) {
 
 	getSql := "SELECT ug.id, ug.name, ug.owner, ug.comment, ug.token, ug.token_enable, ug.ctime, ug.mtime " +
 		"FROM user_group ug WHERE ug.flag = 0 AND ug.id = $1"
-	row := u.master.QueryRow(getSql, groupId)
+	row := u.master.QueryRow("groupStore.GetGroup", getSql, groupId)
 
 	group := &model.UserGroupDetail{
 		UserGroup: &model.UserGroup{},
@@ -269,7 +269,7 @@ func (u *groupStore) GetGroup(groupId string) (*model.UserGroupDetail, error) {
 			return nil, store.Error(err)
 		}
 	}
-	uids, err := u.getGroupLinkUserIds(group.ID)
+	uids, err := u.getGroupLinkUserIds(u.slave.queryHandler("groupStore.GetGroup"), group.ID)
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -283,8 +283,8 @@ func (u *groupStore) GetGroup(groupId string) (*model.UserGroupDetail, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 	}
-	group.CreateTime = time.Unix(int64(ctimeFloat), 0)
-	group.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+	group.CreateTime = epochToTime(ctimeFloat)
+	group.ModifyTime = epochToTime(mtimeFloat)
 
 	group.UserIds = uids
 	group.TokenEnable = tokenEnable == 1
@@ -301,7 +301,7 @@ func (u *groupStore) GetGroupByName(name, owner string) (*model.UserGroup, error
 
 	getSql := "SELECT ug.id, ug.name, ug.owner, ug.comment, ug.token, ug.ctime, ug.mtime FROM user_group ug " +
 		"WHERE ug.flag = 0 AND ug.name = $1 AND ug.owner = $2"
-	row := u.master.QueryRow(getSql, name, owner)
+	row := u.master.QueryRow("groupStore.GetGroupByName", getSql, name, owner)
 
 	group := new(model.UserGroup)
 
@@ -327,8 +327,8 @@ func (u *groupStore) GetGroupByName(name, owner string) (*model.UserGroup, error
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 	}
-	group.CreateTime = time.Unix(int64(ctimeFloat), 0)
-	group.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+	group.CreateTime = epochToTime(ctimeFloat)
+	group.ModifyTime = epochToTime(mtimeFloat)
 
 	return group, nil
 }
@@ -385,7 +385,7 @@ func (u *groupStore) listSimpleGroups(filters map[string]string, offset uint32,
 		}
 	}
 
-	count, err := queryEntryCount(u.master, countSql, args)
+	count, err := queryEntryCount(u.master, "groupStore.listSimpleGroups", countSql, args)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -393,7 +393,7 @@ func (u *groupStore) listSimpleGroups(filters map[string]string, offset uint32,
 	getSql += fmt.Sprintf(" ORDER BY ug.mtime LIMIT $%d OFFSET $%d", idx, idx+1)
 	args = append(args, limit, offset)
 
-	groups, err := u.collectGroupsFromRows(u.master.Query, getSql, args)
+	groups, err := u.collectGroupsFromRows(u.master.queryHandler("groupStore.listSimpleGroups"), getSql, args)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -436,7 +436,7 @@ func (u *groupStore) listGroupByUser(filters map[string]string, offset uint32, l
 		}
 	}
 
-	count, err := queryEntryCount(u.master, countSql, args)
+	count, err := queryEntryCount(u.master, "groupStore.listGroupByUser", countSql, args)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -444,7 +444,7 @@ func (u *groupStore) listGroupByUser(filters map[string]string, offset uint32, l
 	getSql += fmt.Sprintf(" GROUP BY ug.id ORDER BY ug.mtime LIMIT $%d OFFSET $%d", idx, idx+1)
 	args = append(args, limit, offset)
 
-	groups, err := u.collectGroupsFromRows(u.master.Query, getSql, args)
+	groups, err := u.collectGroupsFromRows(u.master.queryHandler("groupStore.listGroupByUser"), getSql, args)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -455,7 +455,7 @@ func (u *groupStore) listGroupByUser(filters map[string]string, offset uint32, l
 // collectGroupsFromRows 查询用户组列表
 func (u *groupStore) collectGroupsFromRows(handler QueryHandler, querySql string,
 	args []interface{}) ([]*model.UserGroup, error) {
-	rows, err := u.master.Query(querySql, args...)
+	rows, err := u.master.Query("groupStore.collectGroupsFromRows", querySql, args...)
 	if err != nil {
 		log.Error("[Store][Group] list group", zap.String("query sql", querySql), zap.Any("args", args))
 		return nil, err
@@ -477,7 +477,8 @@ func (u *groupStore) collectGroupsFromRows(handler QueryHandler, querySql string
 
 // GetGroupsForCache .
 func (u *groupStore) GetGroupsForCache(mtime time.Time, firstUpdate bool) ([]*model.UserGroupDetail, error) {
-	tx, err := u.slave.Begin()
+	// 用户组以及关联的用户在同一个快照中读取
+	tx, err := u.slave.beginCacheLoad("groupStore.GetGroupsForCache")
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -486,9 +487,10 @@ func (u *groupStore) GetGroupsForCache(mtime time.Time, firstUpdate bool) ([]*mo
 
 	args := make([]interface{}, 0)
 	querySql := "SELECT id, name, owner, comment, token, token_enable, ctime, mtime, flag FROM user_group "
+	since := watermarks.since(mtime)
 	if !firstUpdate {
 		querySql += " WHERE mtime >= $1"
-		args = append(args, mtime)
+		args = append(args, since)
 	}
 
 	rows, err := tx.Query(querySql, args...)
@@ -506,18 +508,28 @@ func (u *groupStore) GetGroupsForCache(mtime time.Time, firstUpdate bool) ([]*mo
 		if err != nil {
 			return nil, store.Error(err)
 		}
-		uids, err := u.getGroupLinkUserIds(group.ID)
+		detail.UserGroup = group
+
+		ret = append(ret, detail)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, store.Error(err)
+	}
+	// 同一个连接上不能同时读取多个结果集，读取完用户组之后再查询关联的用户
+	_ = rows.Close()
+
+	for _, detail := range ret {
+		uids, err := u.getGroupLinkUserIds(tx.Query, detail.ID)
 		if err != nil {
 			return nil, store.Error(err)
 		}
-
 		detail.UserIds = uids
-		detail.UserGroup = group
-
-		ret = append(ret, detail)
 	}
 
-	return ret, nil
+	return dedupSlice(watermarks, "user_group", since, firstUpdate, ret,
+		func(detail *model.UserGroupDetail) (string, string) {
+			return detail.ID, rowVersion(detail.ModifyTime)
+		}), nil
 }
 
 func (u *groupStore) addGroupRelation(tx *BaseTx, groupId string, userIds []string) error {
@@ -577,12 +589,12 @@ func (u *groupStore) removeGroupRelation(tx *BaseTx, groupId string, userIds []s
 	return nil
 }
 
-func (u *groupStore) getGroupLinkUserIds(groupId string) (map[string]struct{}, error) {
+func (u *groupStore) getGroupLinkUserIds(handler QueryHandler, groupId string) (map[string]struct{}, error) {
 
 	ids := make(map[string]struct{})
 
 	// 拉取该分组下的所有 user
-	idRows, err := u.slave.Query("SELECT user_id FROM \"user\" u JOIN user_group_relation ug ON "+
+	idRows, err := handler("SELECT user_id FROM \"user\" u JOIN user_group_relation ug ON "+
 		" u.id = ug.user_id WHERE ug.group_id = $1", groupId)
 	if err != nil {
 		return nil, err
@@ -619,8 +631,8 @@ func fetchRown2UserGroup(rows *sql.Rows) (*model.UserGroup, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 	}
-	group.CreateTime = time.Unix(int64(ctimeFloat), 0)
-	group.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+	group.CreateTime = epochToTime(ctimeFloat)
+	group.ModifyTime = epochToTime(mtimeFloat)
 
 	group.Valid = flag == 0
 	group.TokenEnable = tokenEnable == 1
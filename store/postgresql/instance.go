@@ -33,8 +33,8 @@ import (
 
 // instanceStore 实现了InstanceStore接口
 type instanceStore struct {
-	master *BaseDB // 大部分操作都用主数据库
-	slave  *BaseDB // 缓存相关的读取，请求到slave
+	master *BaseDB    // 大部分操作都用主数据库
+	slave  *slavePool // 缓存相关的读取，请求到slave
 }
 
 // AddInstance 添加实例
@@ -47,7 +47,7 @@ func (ins *instanceStore) AddInstance(instance *model.Instance) error {
 
 // addInstance
 func (ins *instanceStore) addInstance(instance *model.Instance) error {
-	tx, err := ins.master.Begin()
+	tx, err := ins.master.Begin("instanceStore.addInstance")
 	if err != nil {
 		log.Errorf("[Store][database] add instance tx begin err: %s", err.Error())
 		return err
@@ -91,7 +91,7 @@ func (ins *instanceStore) BatchAddInstances(instances []*model.Instance) error {
 
 // batchAddInstances batch add instances
 func (ins *instanceStore) batchAddInstances(instances []*model.Instance) error {
-	tx, err := ins.master.Begin()
+	tx, err := ins.master.Begin("instanceStore.batchAddInstances")
 	if err != nil {
 		log.Errorf("[Store][database] batch add instances begin tx err: %s", err.Error())
 		return err
@@ -141,7 +141,7 @@ func (ins *instanceStore) UpdateInstance(instance *model.Instance) error {
 
 // updateInstance update instance
 func (ins *instanceStore) updateInstance(instance *model.Instance) error {
-	tx, err := ins.master.Begin()
+	tx, err := ins.master.Begin("instanceStore.updateInstance")
 	if err != nil {
 		log.Errorf("[Store][database] update instance tx begin err: %s", err.Error())
 		return err
@@ -243,7 +243,7 @@ func (ins *instanceStore) BatchDeleteInstances(ids []interface{}) error {
 				str := "update instance set flag = 1, mtime = CURRENT_TIMESTAMP"
 				placeholder, _ := PlaceholdersNI(len(objects), 1)
 				str += " where id in ( " + placeholder + ")"
-				stmt, err := tx.Prepare(str)
+				stmt, err := tx.prepareDynamic(str)
 				if err != nil {
 					return err
 				}
@@ -291,7 +291,7 @@ func (ins *instanceStore) BatchGetInstanceIsolate(ids map[string]bool) (map[stri
 		args = append(args, key)
 	}
 	instanceIsolate := make(map[string]bool, len(ids))
-	rows, err := ins.master.Query(str, args...)
+	rows, err := ins.master.Query("instanceStore.BatchGetInstanceIsolate", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] check instances existed query err: %s", err.Error())
 		return nil, err
@@ -324,7 +324,7 @@ func (ins *instanceStore) GetInstancesBrief(ids map[string]bool) (map[string]*mo
 		args = append(args, key)
 	}
 
-	rows, err := ins.master.Query(str, args...)
+	rows, err := ins.master.Query("instanceStore.GetInstancesBrief", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get instances service token query err: %s", err.Error())
 		return nil, err
@@ -354,7 +354,7 @@ func (ins *instanceStore) GetInstancesCount() (uint32, error) {
 	var count uint32
 	var err error
 	Retry("query-instance-row", func() error {
-		err = ins.master.QueryRow(countStr).Scan(&count)
+		err = ins.master.QueryRow("instanceStore.GetInstancesCount", countStr).Scan(&count)
 		return err
 	})
 	switch {
@@ -374,7 +374,7 @@ func (ins *instanceStore) GetInstancesCount() (uint32, error) {
 func (ins *instanceStore) GetInstancesMainByService(serviceID, host string) ([]*model.Instance, error) {
 	// 只查询有效的服务实例
 	str := genInstanceSelectSQL() + " where service_id = $1 and host = $2 and flag = 0"
-	rows, err := ins.master.Query(str, serviceID, host)
+	rows, err := ins.master.Query("instanceStore.GetInstancesMainByService", str, serviceID, host)
 	if err != nil {
 		log.Errorf("[Store][database] get instances main query err: %s", err.Error())
 		return nil, err
@@ -427,7 +427,7 @@ func (ins *instanceStore) getExpandInstances(filter, metaFilter map[string]strin
 	order := &Order{"instance.mtime", "desc"}
 	str, args := genWhereSQLAndArgs(str, filter, metaFilter, order, offset, limit)
 
-	rows, err := ins.master.Query(str, args...)
+	rows, err := ins.master.Query("instanceStore.getExpandInstances", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get instance by filters query err: %s, str: %s, args: %v", err.Error(), str, args)
 		return nil, err
@@ -456,7 +456,7 @@ func (ins *instanceStore) getExpandInstancesCount(filter, metaFilter map[string]
 	var count uint32
 	var err error
 	Retry("query-instance-row", func() error {
-		err = ins.master.QueryRow(str, args...).Scan(&count)
+		err = ins.master.QueryRow("instanceStore.getExpandInstancesCount", str, args...).Scan(&count)
 		return err
 	})
 	switch {
@@ -471,6 +471,12 @@ func (ins *instanceStore) getExpandInstancesCount(filter, metaFilter map[string]
 	}
 }
 
+// DefaultFirstLoadChunkSize 首次全量加载实例时每批查询的实例数
+const DefaultFirstLoadChunkSize = 1000
+
+// firstLoadChunkSize 首次全量加载实例的分批大小，由配置项 firstLoadChunkSize 设置
+var firstLoadChunkSize = DefaultFirstLoadChunkSize
+
 // GetMoreInstances 根据mtime获取增量修改数据
 // 这里会返回所有的数据的，包括valid=false的数据
 // 对于首次拉取，firstUpdate=true，只会拉取flag!=1的数据
@@ -478,24 +484,144 @@ func (ins *instanceStore) GetMoreInstances(tx store.Tx, mtime time.Time, firstUp
 	serviceID []string) (map[string]*model.Instance, error) {
 
 	dbTx, _ := tx.GetDelegateTx().(*BaseTx)
-	if needMeta {
-		instances, err := ins.getMoreInstancesMainWithMeta(dbTx, mtime, firstUpdate, serviceID)
+	key := fmt.Sprintf("instance:%t:%s", needMeta, strings.Join(serviceID, ","))
+	// 首次拉取与 StreamInstances 走同一条分批加载路径，读取 cache 事务固定的只读实例及快照
+	// store 接口需要返回全量的 map，分批结果直接写入返回值，不再额外持有整个结果集
+	if firstUpdate {
+		instances := make(map[string]*model.Instance)
+		err := ins.streamInstances(tx, mtime, serviceID, needMeta, firstLoadChunkSize,
+			func(chunk map[string]*model.Instance) error {
+				for id, instance := range chunk {
+					instances[id] = instance
+				}
+				return nil
+			})
 		if err != nil {
 			return nil, err
 		}
+		watermarks.reset(key)
 		return instances, nil
 	}
-	instances, err := ins.getMoreInstancesMain(dbTx, mtime, firstUpdate, serviceID)
+
+	since := watermarks.since(mtime)
+	version := func(instance *model.Instance) string {
+		// 实例的修改时间只精确到秒，每次修改实例都会更新 revision
+		return rowVersion(instance.ModifyTime, instance.Revision())
+	}
+	var (
+		instances map[string]*model.Instance
+		err       error
+	)
+	if needMeta {
+		instances, err = ins.getMoreInstancesMainWithMeta(dbTx, since, serviceID)
+	} else {
+		instances, err = ins.getMoreInstancesMain(dbTx, since, serviceID)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return instances, nil
+	return dedupMap(watermarks, key, since, false, instances, version), nil
+}
+
+// StreamInstances 按实例ID分批加载全量的有效实例，每批调用一次 callback，callback 返回错误时停止加载
+// tx 为调用方的只读事务，为空时在同一个只读实例上开启可重复读事务，整个加载过程读取同一个快照
+func (ins *instanceStore) StreamInstances(tx store.Tx, serviceID []string, needMeta bool,
+	callback func(instances map[string]*model.Instance) error) error {
+	return ins.streamInstances(tx, time.Unix(0, 0), serviceID, needMeta, firstLoadChunkSize, callback)
+}
+
+// streamInstances 以 instance.id 作为游标，在 tx 固定的快照上分批查询 flag != 1 的实例
+func (ins *instanceStore) streamInstances(tx store.Tx, mtime time.Time, serviceID []string, needMeta bool,
+	chunkSize int, callback func(instances map[string]*model.Instance) error) error {
+	if tx == nil {
+		readTx, err := ins.slave.beginCacheLoad("instanceStore.streamInstances")
+		if err != nil {
+			log.Errorf("[Store][database] stream instances begin tx err: %s", err.Error())
+			return err
+		}
+		tx = NewSqlDBTx(readTx)
+		defer func() { _ = tx.Rollback() }()
+	}
+	dbTx, _ := tx.GetDelegateTx().(*BaseTx)
+	if chunkSize <= 0 {
+		chunkSize = DefaultFirstLoadChunkSize
+	}
+	var (
+		lastID string
+		total  int
+	)
+	for {
+		chunk, last, err := ins.loadInstanceChunk(dbTx, mtime, serviceID, needMeta, lastID, chunkSize)
+		if err != nil {
+			return err
+		}
+		if len(chunk) > 0 {
+			if err := callback(chunk); err != nil {
+				return err
+			}
+		}
+		total += len(chunk)
+		if len(chunk) < chunkSize {
+			log.Infof("[Store][database] stream instances finished, total: %d", total)
+			return nil
+		}
+		lastID = last
+	}
+}
+
+// loadInstanceChunk 加载 id 大于 lastID 的一批实例，返回这一批中最大的实例ID
+func (ins *instanceStore) loadInstanceChunk(tx *BaseTx, mtime time.Time, serviceID []string, needMeta bool,
+	lastID string, chunkSize int) (map[string]*model.Instance, string, error) {
+	str := genInstanceSelectSQL() + " where instance.mtime >= $1 and instance.flag != 1 and instance.id > $2"
+	args := make([]interface{}, 0, len(serviceID)+3)
+	args = append(args, mtime, lastID)
+	if len(serviceID) > 0 {
+		placeholder, _ := PlaceholdersNI(len(serviceID), 3)
+		str += " and service_id in (" + placeholder + ")"
+		for _, id := range serviceID {
+			args = append(args, id)
+		}
+	}
+	str += fmt.Sprintf(" order by instance.id limit $%d", len(args)+1)
+	args = append(args, chunkSize)
+
+	rows, err := tx.Query(str, args...)
+	if err != nil {
+		log.Errorf("[Store][database] stream instances query err: %s", err.Error())
+		return nil, "", err
+	}
+	chunk := make(map[string]*model.Instance, chunkSize)
+	ids := make([]interface{}, 0, chunkSize)
+	err = callFetchInstanceRows(rows, func(entry *model.InstanceStore) (bool, error) {
+		chunk[entry.ID] = model.Store2Instance(entry)
+		ids = append(ids, entry.ID)
+		lastID = entry.ID
+		return true, nil
+	})
+	if err != nil {
+		log.Errorf("[Store][database] stream instances fetch rows err: %s", err.Error())
+		return nil, "", err
+	}
+	if !needMeta || len(ids) == 0 {
+		return chunk, lastID, nil
+	}
+
+	placeholder, _ := PlaceholdersNI(len(ids), 1)
+	rows, err = tx.Query("select id, mkey, mvalue from instance_metadata where id in ("+placeholder+")", ids...)
+	if err != nil {
+		log.Errorf("[Store][database] stream instances meta query err: %s", err.Error())
+		return nil, "", err
+	}
+	if err := fetchInstanceMetaRows(chunk, rows); err != nil {
+		return nil, "", err
+	}
+	return chunk, lastID, nil
 }
 
 // GetInstanceMeta 根据实例ID获取实例的metadata
 func (ins *instanceStore) GetInstanceMeta(instanceID string) (map[string]string, error) {
 	str := "select mkey, mvalue from instance_metadata where id = $1"
-	rows, err := ins.master.Query(str, instanceID)
+	rows, err := ins.master.Query("instanceStore.GetInstanceMeta", str, instanceID)
 	if err != nil {
 		log.Errorf("[Store][database] query instance meta err: %s", err.Error())
 		return nil, err
@@ -562,7 +688,7 @@ func (ins *instanceStore) BatchSetInstanceHealthStatus(ids []interface{}, isolat
 				args = append(args, revision)
 				args = append(args, objects...)
 
-				stmt, err := tx.Prepare(str)
+				stmt, err := tx.prepareDynamic(str)
 				if err != nil {
 					return store.Error(err)
 				}
@@ -601,7 +727,7 @@ func (ins *instanceStore) BatchSetInstanceIsolate(ids []interface{}, isolate int
 				args = append(args, revision)
 				args = append(args, objects...)
 
-				stmt, err := tx.Prepare(str)
+				stmt, err := tx.prepareDynamic(str)
 				if err != nil {
 					return store.Error(err)
 				}
@@ -645,7 +771,7 @@ func (ins *instanceStore) BatchAppendInstanceMetadata(requests []*store.Instance
 			if log.DebugEnabled() {
 				log.Debug("[Store][database] append instance metadata", zap.String("sql", str), zap.Any("args", args))
 			}
-			stmt, err := tx.Prepare(str)
+			stmt, err := tx.prepareDynamic(str)
 			if err != nil {
 				return err
 			}
@@ -689,7 +815,7 @@ func (ins *instanceStore) BatchRemoveInstanceMetadata(requests []*store.Instance
 				args = append(args, key)
 			}
 			str = fmt.Sprintf(str, 1, strings.Join(values, ","))
-			stmt, err := tx.Prepare(str)
+			stmt, err := tx.prepareDynamic(str)
 			if err != nil {
 				return err
 			}
@@ -715,7 +841,7 @@ func (ins *instanceStore) BatchRemoveInstanceMetadata(requests []*store.Instance
 // getInstance 内部获取instance函数，根据instanceID，直接读取元数据，不做其他过滤
 func (ins *instanceStore) getInstance(instanceID string) (*model.Instance, error) {
 	str := genInstanceSelectSQL() + " where instance.id = $1"
-	rows, err := ins.master.Query(str, instanceID)
+	rows, err := ins.master.Query("instanceStore.getInstance", str, instanceID)
 	if err != nil {
 		log.Errorf("[Store][database] get instance query err: %s", err.Error())
 		return nil, err
@@ -741,30 +867,8 @@ func (ins *instanceStore) getInstance(instanceID string) (*model.Instance, error
 
 // getMoreInstancesMainWithMeta 获取增量instance+healthcheck+meta内容
 // @note ro库有多个实例，且主库到ro库各实例的同步时间不一致。为避免获取不到meta，需要采用一条sql语句获取全部数据
-func (ins *instanceStore) getMoreInstancesMainWithMeta(tx *BaseTx, mtime time.Time, firstUpdate bool, serviceID []string) (
+func (ins *instanceStore) getMoreInstancesMainWithMeta(tx *BaseTx, mtime time.Time, serviceID []string) (
 	map[string]*model.Instance, error) {
-	// 首次拉取
-	if firstUpdate {
-		// 获取全量服务实例
-		instances, err := ins.getMoreInstancesMain(tx, mtime, firstUpdate, serviceID)
-		if err != nil {
-			log.Errorf("[Store][database] get more instance main err: %s", err.Error())
-			return nil, err
-		}
-		// 获取全量服务实例元数据
-		str := "select id, mkey, mvalue from instance_metadata"
-		rows, err := ins.slave.Query(str)
-		if err != nil {
-			log.Errorf("[Store][database] acquire instances meta query err: %s", err.Error())
-			return nil, err
-		}
-		if err := fetchInstanceMetaRows(instances, rows); err != nil {
-			return nil, err
-		}
-		return instances, nil
-	}
-
-	// 非首次拉取
 	var index = 1
 	str := genCompleteInstanceSelectSQL() + fmt.Sprintf(" where instance.mtime >= $%d", index)
 	args := make([]interface{}, 0, len(serviceID)+1)
@@ -779,7 +883,7 @@ func (ins *instanceStore) getMoreInstancesMainWithMeta(tx *BaseTx, mtime time.Ti
 		args = append(args, id)
 	}
 
-	rows, err := ins.slave.Query(str, args...)
+	rows, err := tx.Query(str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get more instance query err: %s", err.Error())
 		return nil, err
@@ -846,17 +950,13 @@ func fetchInstanceWithMetaRows(rows *sql.Rows) (map[string]*model.Instance, erro
 }
 
 // getMoreInstancesMain 获取增量instances 主表内容，health_check内容
-func (ins *instanceStore) getMoreInstancesMain(tx *BaseTx, mtime time.Time, firstUpdate bool, serviceID []string) (
+func (ins *instanceStore) getMoreInstancesMain(tx *BaseTx, mtime time.Time, serviceID []string) (
 	map[string]*model.Instance, error) {
 	var index = 1
 	str := genInstanceSelectSQL() + fmt.Sprintf(" where instance.mtime >= $%d", index)
 	args := make([]interface{}, 0, len(serviceID)+1)
 	args = append(args, mtime)
 
-	if firstUpdate {
-		str += " and flag != 1"
-	}
-
 	if len(serviceID) > 0 {
 		placeholder, _ := PlaceholdersNI(len(serviceID), index+1)
 		str += " and service_id in (" + placeholder
@@ -911,7 +1011,7 @@ func (ins *instanceStore) getRowExpandInstances(rows *sql.Rows) ([]*model.Instan
 // batchAcquireInstanceMetadata 批量获取instance的metadata信息
 // web端获取实例的数据的时候使用
 func (ins *instanceStore) batchAcquireInstanceMetadata(instances []interface{}) error {
-	rows, err := batchQueryMetadata(ins.master.Query, instances)
+	rows, err := batchQueryMetadata(ins.master.queryHandler("instanceStore.batchAcquireInstanceMetadata"), instances)
 	if err != nil {
 		return err
 	}
@@ -1058,7 +1158,7 @@ func batchAddMainInstances(tx *BaseTx, instances []*model.Instance) error {
 			entry.Location().GetRegion().GetValue(), entry.Location().GetZone().GetValue(),
 			entry.Location().GetCampus().GetValue(), entry.Priority(), entry.Revision())
 	}
-	stmt, err := tx.Prepare(str)
+	stmt, err := tx.prepareDynamic(str)
 	if err != nil {
 		return err
 	}
@@ -1106,7 +1206,7 @@ func batchAddInstanceCheck(tx *BaseTx, instances []*model.Instance) error {
 	if first {
 		return nil
 	}
-	stmt, err := tx.Prepare(str)
+	stmt, err := tx.prepareDynamic(str)
 	if err != nil {
 		return err
 	}
@@ -1140,7 +1240,7 @@ func addInstanceMeta(tx *BaseTx, id string, meta map[string]string) error {
 		args = append(args, value)
 	}
 
-	stmt, err := tx.Prepare(str)
+	stmt, err := tx.prepareDynamic(str)
 	if err != nil {
 		return err
 	}
@@ -1168,7 +1268,7 @@ func batchDeleteInstanceMeta(tx *BaseTx, instances []*model.Instance) error {
 	}
 
 	str := "delete from instance_metadata where id in (" + builder.String() + ")"
-	stmt, err := tx.Prepare(str)
+	stmt, err := tx.prepareDynamic(str)
 	if err != nil {
 		return err
 	}
@@ -1203,7 +1303,7 @@ func batchAddInstanceMeta(tx *BaseTx, instances []*model.Instance) error {
 		return nil
 	}
 
-	stmt, err := tx.Prepare(str)
+	stmt, err := tx.prepareDynamic(str)
 	if err != nil {
 		return err
 	}
@@ -18,13 +18,18 @@
 package postgresql
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/polarismesh/polaris/common/model"
+	"github.com/polarismesh/polaris/common/utils"
 	"github.com/polarismesh/polaris/store"
 	apiservice "github.com/polarismesh/specification/source/go/api/v1/service_manage"
+	. "github.com/smartystreets/goconvey/convey"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -248,6 +253,89 @@ func TestGetMoreInstances(t *testing.T) {
 	fmt.Printf("resp: %+v, err: %+v", obj, obj)
 }
 
+func TestStreamInstances(t *testing.T) {
+	obj := requireDB(t)
+	serviceID := utils.NewUUID()
+	expect := make(map[string]bool)
+	instances := make([]*model.Instance, 0, 5)
+	for i := 0; i < 5; i++ {
+		id := utils.NewUUID()
+		expect[id] = true
+		instances = append(instances, &model.Instance{
+			Proto: &apiservice.Instance{
+				Id:       wrapperspb.String(id),
+				Host:     wrapperspb.String("127.0.0.1"),
+				Port:     wrapperspb.UInt32(uint32(8000 + i)),
+				Healthy:  wrapperspb.Bool(true),
+				Isolate:  wrapperspb.Bool(false),
+				Metadata: map[string]string{"index": strconv.Itoa(i)},
+			},
+			ServiceID: serviceID,
+			Valid:     true,
+		})
+	}
+	if err := obj.BatchAddInstances(instances); err != nil {
+		t.Fatalf("add instances err: %s", err.Error())
+	}
+	defer func() {
+		ids := make([]interface{}, 0, len(instances))
+		for _, instance := range instances {
+			ids = append(ids, instance.ID())
+		}
+		_ = obj.BatchDeleteInstances(ids)
+	}()
+
+	Convey("按实例ID分批加载，每批不超过分批大小且携带元数据", t, func() {
+		var (
+			chunks int
+			lastID string
+		)
+		got := make(map[string]bool)
+		err := obj.instanceStore.streamInstances(nil, time.Unix(0, 0), []string{serviceID}, true, 2,
+			func(chunk map[string]*model.Instance) error {
+				chunks++
+				So(len(chunk), ShouldBeLessThanOrEqualTo, 2)
+				ids := make([]string, 0, len(chunk))
+				for id, instance := range chunk {
+					So(instance.Metadata(), ShouldContainKey, "index")
+					got[id] = true
+					ids = append(ids, id)
+				}
+				sort.Strings(ids)
+				So(ids[0], ShouldBeGreaterThan, lastID)
+				lastID = ids[len(ids)-1]
+				return nil
+			})
+		So(err, ShouldBeNil)
+		So(chunks, ShouldEqual, 3)
+		So(got, ShouldResemble, expect)
+	})
+	Convey("callback 返回错误时停止加载", t, func() {
+		stop := errors.New("stop")
+		chunks := 0
+		err := obj.instanceStore.streamInstances(nil, time.Unix(0, 0), []string{serviceID}, false, 2,
+			func(chunk map[string]*model.Instance) error {
+				chunks++
+				return stop
+			})
+		So(err, ShouldEqual, stop)
+		So(chunks, ShouldEqual, 1)
+	})
+	Convey("cache 首次加载与分批加载的结果一致", t, func() {
+		tx, err := obj.StartReadTx()
+		So(err, ShouldBeNil)
+		defer func() { _ = tx.Rollback() }()
+		So(tx.CreateReadView(), ShouldBeNil)
+		got, err := obj.GetMoreInstances(tx, time.Unix(0, 0), true, true, []string{serviceID})
+		So(err, ShouldBeNil)
+		So(len(got), ShouldEqual, len(expect))
+		for id, instance := range got {
+			So(expect, ShouldContainKey, id)
+			So(instance.Metadata(), ShouldContainKey, "index")
+		}
+	})
+}
+
 func TestSetInstanceHealthStatus(t *testing.T) {
 	obj := initConf()
 	err := obj.instanceStore.SetInstanceHealthStatus("1111", 1, "reversion")
@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -27,8 +28,8 @@ import (
 
 // l5Store 实现了L5Store
 type l5Store struct {
-	master *BaseDB // 大部分操作都用主数据库
-	slave  *BaseDB // 缓存相关的读取，请求到slave
+	master *BaseDB    // 大部分操作都用主数据库
+	slave  *slavePool // 缓存相关的读取，请求到slave
 }
 
 // GetL5Extend 获取L5扩展数据
@@ -56,7 +57,7 @@ func (l5 *l5Store) GenNextL5Sid(layoutID uint32) (string, error) {
 
 // genNextL5Sid
 func (l5 *l5Store) genNextL5Sid(layoutID uint32) (string, error) {
-	tx, err := l5.master.Begin()
+	tx, err := l5.master.Begin("l5Store.genNextL5Sid")
 	if err != nil {
 		log.Errorf("[Store][database] get next l5 sid tx begin err: %s", err.Error())
 		return "", err
@@ -109,7 +110,9 @@ func (l5 *l5Store) GetMoreL5Extend(mtime time.Time) (map[string]map[string]inter
 // GetMoreL5Routes 获取更多的L5 Route信息
 func (l5 *l5Store) GetMoreL5Routes(flow uint32) ([]*model.Route, error) {
 	str := getL5RouteSelectSQL() + " where Fflow > $1"
-	rows, err := l5.slave.Query(str, flow)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := l5.slave.cacheReader().QueryContext(ctx, "l5Store.GetMoreL5Routes", str, flow)
 	if err != nil {
 		log.Errorf("[Store][database] get more l5 route query err: %s", err.Error())
 		return nil, err
@@ -121,7 +124,9 @@ func (l5 *l5Store) GetMoreL5Routes(flow uint32) ([]*model.Route, error) {
 // GetMoreL5Policies 获取更多的L5 Policy信息
 func (l5 *l5Store) GetMoreL5Policies(flow uint32) ([]*model.Policy, error) {
 	str := getL5PolicySelectSQL() + " where Fflow > $1"
-	rows, err := l5.slave.Query(str, flow)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := l5.slave.cacheReader().QueryContext(ctx, "l5Store.GetMoreL5Policies", str, flow)
 	if err != nil {
 		log.Errorf("[Store][database] get more l5 policy query err: %s", err.Error())
 		return nil, err
@@ -133,7 +138,9 @@ func (l5 *l5Store) GetMoreL5Policies(flow uint32) ([]*model.Policy, error) {
 // GetMoreL5Sections 获取更多的L5 Section信息
 func (l5 *l5Store) GetMoreL5Sections(flow uint32) ([]*model.Section, error) {
 	str := getL5SectionSelectSQL() + " where Fflow > $1"
-	rows, err := l5.slave.Query(str, flow)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := l5.slave.cacheReader().QueryContext(ctx, "l5Store.GetMoreL5Sections", str, flow)
 	if err != nil {
 		log.Errorf("[Store][database] get more l5 section query err: %s", err.Error())
 		return nil, err
@@ -145,7 +152,9 @@ func (l5 *l5Store) GetMoreL5Sections(flow uint32) ([]*model.Section, error) {
 // GetMoreL5IPConfigs 获取更多的L5 IPConfig信息
 func (l5 *l5Store) GetMoreL5IPConfigs(flow uint32) ([]*model.IPConfig, error) {
 	str := getL5IPConfigSelectSQL() + " where Fflow > $1"
-	rows, err := l5.slave.Query(str, flow)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := l5.slave.cacheReader().QueryContext(ctx, "l5Store.GetMoreL5IPConfigs", str, flow)
 	if err != nil {
 		log.Errorf("[Store][database] get more l5 ip config query err: %s", err.Error())
 		return nil, err
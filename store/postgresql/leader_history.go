@@ -0,0 +1,108 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/polarismesh/polaris/common/utils"
+	"github.com/polarismesh/polaris/store"
+)
+
+const (
+	// LeaderRole 节点变更为 leader
+	LeaderRole = "leader"
+	// FollowerRole 节点变更为 follower
+	FollowerRole = "follower"
+)
+
+// LeaderElectionHistory 一次 leader 选举的角色变更记录
+type LeaderElectionHistory struct {
+	ID       int64
+	ElectKey string
+	// Host 发生角色变更的节点
+	Host string
+	// Role 变更后的角色，LeaderRole 或 FollowerRole
+	Role string
+	// Leader 变更后该节点看到的 leader，未知时为空
+	Leader string
+	// FencingToken 变更时节点持有的 fencing token，变更为 follower 时为刚结束的任期的 token
+	FencingToken int64
+	CreateTime   time.Time
+}
+
+// leaderHistoryStore 记录以及查询 leader 选举的角色变更
+type leaderHistoryStore struct {
+	master *BaseDB
+}
+
+// record 写入一次角色变更，写入失败只打印日志，不影响选举
+func (h *leaderHistoryStore) record(key string, role string, leader string, token int64) {
+	_, err := h.master.Exec("leaderHistoryStore.record",
+		"INSERT INTO leader_election_history(elect_key, host, role, leader, fencing_token) "+
+			"VALUES ($1, $2, $3, $4, $5)", key, utils.LocalHost, role, leader, token)
+	if err != nil {
+		log.Errorf("[Store][database] record leader election history (%s, %s), err: %s", key, role, err.Error())
+	}
+}
+
+// ListLeaderElectionHistory 按选举键查询 [start, end) 时间范围内的角色变更，按发生顺序返回，零值的时间表示不限制
+func (h *leaderHistoryStore) ListLeaderElectionHistory(key string, start, end time.Time) (
+	[]*LeaderElectionHistory, error) {
+	conditions := []string{"elect_key = $1"}
+	args := []interface{}{key}
+	if !start.IsZero() {
+		args = append(args, start)
+		conditions = append(conditions, fmt.Sprintf("ctime >= $%d", len(args)))
+	}
+	if !end.IsZero() {
+		args = append(args, end)
+		conditions = append(conditions, fmt.Sprintf("ctime < $%d", len(args)))
+	}
+	mainStr := "SELECT id, elect_key, host, role, leader, fencing_token, EXTRACT(EPOCH FROM ctime) " +
+		"FROM leader_election_history WHERE " + strings.Join(conditions, " AND ") + " ORDER BY id"
+
+	rows, err := h.master.Query("leaderHistoryStore.ListLeaderElectionHistory", mainStr, args...)
+	if err != nil {
+		log.Errorf("[Store][database] list leader election history (%s), err: %s", key, err.Error())
+		return nil, store.Error(err)
+	}
+	defer rows.Close()
+
+	var out []*LeaderElectionHistory
+	for rows.Next() {
+		var (
+			item  = &LeaderElectionHistory{}
+			ctime float64
+		)
+		if err := rows.Scan(&item.ID, &item.ElectKey, &item.Host, &item.Role, &item.Leader,
+			&item.FencingToken, &ctime); err != nil {
+			log.Errorf("[Store][database] fetch leader election history rows scan err: %s", err.Error())
+			return nil, store.Error(err)
+		}
+		item.CreateTime = epochToTime(ctime)
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("[Store][database] fetch leader election history rows next err: %s", err.Error())
+		return nil, store.Error(err)
+	}
+	return out, nil
+}
@@ -0,0 +1,144 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/polarismesh/polaris/common/model"
+	"github.com/polarismesh/polaris/common/utils"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// memLeaderElectionStore 内存中的选举后端，expired 模拟 leader 租期是否过期
+type memLeaderElectionStore struct {
+	version int64
+	leader  string
+	expired bool
+}
+
+func (m *memLeaderElectionStore) CreateLeaderElection(string) error {
+	return nil
+}
+
+func (m *memLeaderElectionStore) GetVersion(string) (int64, error) {
+	return m.version, nil
+}
+
+func (m *memLeaderElectionStore) CompareAndSwapVersion(_ string, curVersion int64, newVersion int64,
+	leader string) (bool, error) {
+	if m.version != curVersion {
+		return false, nil
+	}
+	m.version, m.leader, m.expired = newVersion, leader, false
+	return true, nil
+}
+
+func (m *memLeaderElectionStore) CheckMtimeExpired(string, int32) (string, bool, error) {
+	return m.leader, m.expired, nil
+}
+
+func (m *memLeaderElectionStore) ListLeaderElections() ([]*model.LeaderElection, error) {
+	return nil, nil
+}
+
+func TestFencingToken(t *testing.T) {
+	Convey("fencing token 在每个任期单调递增", t, func() {
+		mem := &memLeaderElectionStore{expired: true}
+		le := &leaderElectionStateMachine{electKey: TestElectKey, leStore: mem}
+		admin := &adminStore{leStore: mem, leMap: map[string]*leaderElectionStateMachine{TestElectKey: le}}
+
+		_, ok := admin.FencingToken(TestElectKey)
+		So(ok, ShouldBeFalse)
+
+		le.tick()
+		token, ok := admin.FencingToken(TestElectKey)
+		So(ok, ShouldBeTrue)
+		So(token, ShouldEqual, 1)
+
+		// 心跳只续约，不改变当前任期的 token
+		le.tick()
+		So(mem.version, ShouldEqual, 2)
+		token, ok = admin.FencingToken(TestElectKey)
+		So(ok, ShouldBeTrue)
+		So(token, ShouldEqual, 1)
+
+		// 其他节点接管后本节点失去 token
+		mem.version, mem.leader = 10, "other"
+		le.tick()
+		_, ok = admin.FencingToken(TestElectKey)
+		So(ok, ShouldBeFalse)
+		So(le.leader, ShouldEqual, "other")
+
+		mem.expired = true
+		le.tick()
+		token, ok = admin.FencingToken(TestElectKey)
+		So(ok, ShouldBeTrue)
+		So(token, ShouldEqual, 11)
+	})
+	Convey("租期内的本机记录需要重新竞选才能恢复为 leader", t, func() {
+		mem := &memLeaderElectionStore{version: 5, leader: utils.LocalHost}
+		le := &leaderElectionStateMachine{electKey: TestElectKey, leStore: mem}
+		le.tick()
+		So(le.isLeader(), ShouldBeTrue)
+		So(le.fencingToken, ShouldEqual, 6)
+	})
+}
+
+func TestLeaderElectionHistory(t *testing.T) {
+	obj := requireDB(t)
+	key := "test-history-" + utils.NewUUID()
+	start := time.Now().Add(-time.Second)
+	mem := &memLeaderElectionStore{version: 5, leader: "other"}
+	ctx, cancel := context.WithCancel(context.Background())
+	le := &leaderElectionStateMachine{electKey: key, leStore: mem, ctx: ctx, cancel: cancel,
+		history: obj.adminStore.history}
+
+	Convey("只记录真实的角色变更并按时间范围查询", t, func() {
+		// 启动以及观察到其他节点之间的 leader 变化都不是本节点的角色变更
+		le.changeToFollower("")
+		le.tick()
+		mem.leader = "another"
+		le.tick()
+		list, err := obj.adminStore.ListLeaderElectionHistory(key, start, time.Time{})
+		So(err, ShouldBeNil)
+		So(list, ShouldBeEmpty)
+
+		mem.expired = true
+		le.tick()
+		le.tick()
+		mem.version, mem.leader = 10, "other"
+		le.tick()
+		le.changeToFollower("")
+
+		list, err = obj.adminStore.ListLeaderElectionHistory(key, start, time.Time{})
+		So(err, ShouldBeNil)
+		So(list, ShouldHaveLength, 2)
+		So(list[0].Role, ShouldEqual, LeaderRole)
+		So(list[0].FencingToken, ShouldEqual, 6)
+		So(list[0].Host, ShouldEqual, utils.LocalHost)
+		So(list[1].Role, ShouldEqual, FollowerRole)
+		So(list[1].Leader, ShouldEqual, "other")
+
+		list, err = obj.adminStore.ListLeaderElectionHistory(key, start, start)
+		So(err, ShouldBeNil)
+		So(list, ShouldBeEmpty)
+	})
+}
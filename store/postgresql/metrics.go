@@ -0,0 +1,232 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/polarismesh/polaris/common/metrics"
+	"github.com/polarismesh/polaris/common/utils"
+	"github.com/polarismesh/polaris/plugin"
+	"github.com/polarismesh/polaris/store"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	labelSlave = "slave"
+	labelDB    = "db"
+	labelTable = "table"
+
+	// DefaultDBStatsInterval 连接池统计信息的默认上报间隔，单位秒
+	DefaultDBStatsInterval = 10
+	// unknownCaller 无法识别调用方时使用的标签
+	unknownCaller = "unknown"
+)
+
+var (
+	registerMetricsOnce sync.Once
+
+	// slaveReplicationLag 只读实例的复制延迟，单位秒
+	slaveReplicationLag *prometheus.GaugeVec
+
+	// 连接池统计信息，对应 sql.DBStats
+	dbOpenConnections  *prometheus.GaugeVec
+	dbInUseConnections *prometheus.GaugeVec
+	dbIdleConnections  *prometheus.GaugeVec
+	dbWaitCount        *prometheus.GaugeVec
+	dbWaitDuration     *prometheus.GaugeVec
+
+	// 软删除数据清理的进度
+	purgeRows       *prometheus.CounterVec
+	purgeBatches    *prometheus.CounterVec
+	purgeErrors     *prometheus.CounterVec
+	purgeLastDelete *prometheus.GaugeVec
+)
+
+// registerStoreMetrics 注册 store 自身的指标
+func registerStoreMetrics() {
+	registerMetricsOnce.Do(func() {
+		slaveReplicationLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "store_slave_replication_lag",
+			Help: "replication lag seconds of postgresql slave",
+			ConstLabels: map[string]string{
+				"polaris_server_instance": utils.LocalHost,
+			},
+		}, []string{labelSlave})
+
+		dbOpenConnections = newDBStatsGauge("store_db_open_connections",
+			"number of established connections both in use and idle")
+		dbInUseConnections = newDBStatsGauge("store_db_in_use_connections",
+			"number of connections currently in use")
+		dbIdleConnections = newDBStatsGauge("store_db_idle_connections", "number of idle connections")
+		dbWaitCount = newDBStatsGauge("store_db_wait_count", "total number of connections waited for")
+		dbWaitDuration = newDBStatsGauge("store_db_wait_duration",
+			"total seconds blocked waiting for a new connection")
+
+		purgeRows = newPurgeCounter("store_purge_rows_total", "number of soft deleted rows purged")
+		purgeBatches = newPurgeCounter("store_purge_batches_total", "number of purge batches executed")
+		purgeErrors = newPurgeCounter("store_purge_errors_total", "number of failed purge batches")
+		purgeLastDelete = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "store_purge_last_delete_timestamp",
+			Help: "unix timestamp of the last purge batch which deleted rows",
+			ConstLabels: map[string]string{
+				"polaris_server_instance": utils.LocalHost,
+			},
+		}, []string{labelTable})
+
+		for _, collector := range []prometheus.Collector{slaveReplicationLag, dbOpenConnections,
+			dbInUseConnections, dbIdleConnections, dbWaitCount, dbWaitDuration,
+			purgeRows, purgeBatches, purgeErrors, purgeLastDelete} {
+			_ = metrics.GetRegistry().Register(collector)
+		}
+	})
+}
+
+// newDBStatsGauge 新建连接池统计信息的指标，以 db 区分主库和各只读实例
+func newDBStatsGauge(name, help string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: name,
+		Help: help,
+		ConstLabels: map[string]string{
+			"polaris_server_instance": utils.LocalHost,
+		},
+	}, []string{labelDB})
+}
+
+// newPurgeCounter 新建软删除数据清理的指标，以 table 区分各个主表
+func newPurgeCounter(name, help string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: name,
+		Help: help,
+		ConstLabels: map[string]string{
+			"polaris_server_instance": utils.LocalHost,
+		},
+	}, []string{labelTable})
+}
+
+// reportPurge 上报一批软删除数据的清理结果
+func reportPurge(table string, rows int64, err error) {
+	registerStoreMetrics()
+	purgeBatches.WithLabelValues(table).Inc()
+	if err != nil {
+		purgeErrors.WithLabelValues(table).Inc()
+		return
+	}
+	if rows > 0 {
+		purgeRows.WithLabelValues(table).Add(float64(rows))
+		purgeLastDelete.WithLabelValues(table).Set(float64(time.Now().Unix()))
+	}
+}
+
+// reportSlaveLag 上报只读实例的复制延迟
+func reportSlaveLag(slave string, seconds float64) {
+	registerStoreMetrics()
+	slaveReplicationLag.WithLabelValues(slave).Set(seconds)
+}
+
+// reportDBStats 上报单个数据库连接池的统计信息
+func reportDBStats(db string, stats sql.DBStats) {
+	registerStoreMetrics()
+	dbOpenConnections.WithLabelValues(db).Set(float64(stats.OpenConnections))
+	dbInUseConnections.WithLabelValues(db).Set(float64(stats.InUse))
+	dbIdleConnections.WithLabelValues(db).Set(float64(stats.Idle))
+	dbWaitCount.WithLabelValues(db).Set(float64(stats.WaitCount))
+	dbWaitDuration.WithLabelValues(db).Set(stats.WaitDuration.Seconds())
+}
+
+// runDBStatsReporter 定时上报主库及所有只读实例的连接池统计信息，ctx 结束后退出
+func runDBStatsReporter(ctx context.Context, interval time.Duration, master *BaseDB, slaves *slavePool) {
+	if interval <= 0 {
+		interval = DefaultDBStatsInterval * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		reportDBStats("master", master.Stats())
+		for _, node := range slaves.nodes {
+			reportDBStats("slave:"+node.name(), node.Stats())
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reportCallMetrics 上报一次数据库调用，API 为发起调用的 store 方法 label，如 instanceStore.GetMoreInstances
+func reportCallMetrics(label string, operation string, start time.Time, err error) {
+	storeName, method := splitCallLabel(label)
+	if label == "" {
+		label = unknownCaller
+	}
+	labels := map[string]string{
+		"store":     storeName,
+		"method":    method,
+		"operation": operation,
+	}
+	code := 0
+	if err != nil {
+		code = int(store.Code(store.Error(err)))
+		if code := sqlState(err); code != "" {
+			labels["sqlstate"] = code
+		}
+	}
+	plugin.GetStatis().ReportCallMetrics(metrics.CallMetric{
+		Type:             metrics.StoreCallMetric,
+		API:              label,
+		Protocol:         "PostgreSQL",
+		Code:             code,
+		Times:            1,
+		Success:          err == nil,
+		Duration:         time.Since(start),
+		Labels:           labels,
+		TrafficDirection: metrics.TrafficDirectionOutBound,
+	})
+}
+
+// callLabelKey context 中记录发起调用的 store 方法的 key
+type callLabelKey struct{}
+
+// withCallLabel 在 ctx 中记录发起调用的 store 方法，慢查询日志从语句的 ctx 中读取
+func withCallLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, callLabelKey{}, label)
+}
+
+// callLabel 读取 ctx 中记录的 store 方法，未记录时返回空
+func callLabel(ctx context.Context) string {
+	label, _ := ctx.Value(callLabelKey{}).(string)
+	return label
+}
+
+// splitCallLabel 将 instanceStore.GetMoreInstances 拆分为 instanceStore 和 GetMoreInstances
+// 不带 store 名称的 label 整体作为方法名，如 processWithTransaction 的 label
+func splitCallLabel(label string) (string, string) {
+	if label == "" {
+		return "", unknownCaller
+	}
+	if idx := strings.Index(label, "."); idx > 0 {
+		return label[:idx], label[idx+1:]
+	}
+	return "", label
+}
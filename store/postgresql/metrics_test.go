@@ -0,0 +1,41 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSplitCallLabel(t *testing.T) {
+	Convey("拆分调用方 label", t, func() {
+		cases := []struct {
+			label, store, method string
+		}{
+			{"instanceStore.GetMoreInstances", "instanceStore", "GetMoreInstances"},
+			{"batchAddInstances", "", "batchAddInstances"},
+			{"", "", unknownCaller},
+		}
+		for _, c := range cases {
+			storeName, method := splitCallLabel(c.label)
+			So(storeName, ShouldEqual, c.store)
+			So(method, ShouldEqual, c.method)
+		}
+	})
+}
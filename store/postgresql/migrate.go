@@ -0,0 +1,183 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// migrationTable 记录已执行的迁移版本
+	migrationTable = "schema_migrations"
+	// migrationLockSql 以 schema 为粒度加会话级 advisory lock，同一时间只有一个节点执行迁移
+	migrationLockSql   = "SELECT pg_advisory_lock(hashtext(current_schema() || '." + migrationTable + "'))"
+	migrationUnlockSql = "SELECT pg_advisory_unlock(hashtext(current_schema() || '." + migrationTable + "'))"
+
+	createMigrationTableSql = "CREATE TABLE IF NOT EXISTS " + migrationTable + " (" +
+		"version int8 NOT NULL PRIMARY KEY, " +
+		"name varchar(255) NOT NULL, " +
+		"applied_at timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP)"
+
+	// noTransactionDirective 以该注释开头的迁移不在事务中执行，由迁移自身控制事务的提交
+	// 这类迁移中断后会从头重新执行，需要保证已经完成的部分可以重复执行
+	noTransactionDirective = "-- migrate:no-transaction"
+)
+
+// migrationFiles 内置的迁移文件，文件名格式为 <版本号>_<描述>.sql，按版本号从小到大执行
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration 单个版本的迁移
+type migration struct {
+	version int64
+	name    string
+	sql     string
+	// noTx 是否不在事务中执行
+	noTx bool
+}
+
+// loadMigrations 加载内置的迁移文件并按版本号排序
+func loadMigrations() ([]*migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+	migrations := make([]*migration, 0, len(entries))
+	versions := make(map[int64]string, len(entries))
+	for _, entry := range entries {
+		version, name, err := parseMigrationName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if exist, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migration %s and %s have the same version %d", exist, entry.Name(), version)
+		}
+		versions[version] = entry.Name()
+
+		content, err := migrationFiles.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, &migration{version: version, name: name, sql: string(content),
+			noTx: strings.HasPrefix(string(content), noTransactionDirective)})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+// parseMigrationName 从文件名中解析版本号及描述，如 0001_upgrade_v1_17_2.sql
+func parseMigrationName(file string) (int64, string, error) {
+	base := strings.TrimSuffix(file, ".sql")
+	idx := strings.Index(base, "_")
+	if base == file || idx <= 0 || idx == len(base)-1 {
+		return 0, "", fmt.Errorf("migration file name %s must be <version>_<name>.sql", file)
+	}
+	version, err := strconv.ParseInt(base[:idx], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, "", fmt.Errorf("migration file name %s has invalid version", file)
+	}
+	return version, base[idx+1:], nil
+}
+
+// migrate 在 advisory lock 的保护下依次执行未执行过的迁移，每个版本在独立的事务中执行
+// 声明了 noTransactionDirective 的版本直接在连接上执行，执行成功后再记录版本
+func (b *BaseDB) migrate(ctx context.Context) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	// advisory lock 为会话级别，加锁、执行迁移以及解锁需要使用同一个连接
+	conn, err := b.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if _, err = conn.ExecContext(ctx, migrationLockSql); err != nil {
+		return err
+	}
+	defer func() {
+		if _, unlockErr := conn.ExecContext(context.Background(), migrationUnlockSql); unlockErr != nil {
+			log.Errorf("[Store][database] release migration lock err: %s", unlockErr.Error())
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, createMigrationTableSql); err != nil {
+		return err
+	}
+	applied := make(map[int64]struct{})
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM "+migrationTable)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var version int64
+		if err = rows.Scan(&version); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		applied[version] = struct{}{}
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if _, ok := applied[m.version]; ok {
+			continue
+		}
+		log.Infof("[Store][database] apply migration %d_%s", m.version, m.name)
+		if m.noTx {
+			if _, err = conn.ExecContext(ctx, m.sql); err == nil {
+				_, err = conn.ExecContext(ctx, "INSERT INTO "+migrationTable+" (version, name) VALUES ($1, $2)",
+					m.version, m.name)
+			}
+			if err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", m.version, m.name, err)
+			}
+			continue
+		}
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, m.sql); err == nil {
+			_, err = tx.ExecContext(ctx, "INSERT INTO "+migrationTable+" (version, name) VALUES ($1, $2)",
+				m.version, m.name)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("apply migration %d_%s: %w", m.version, m.name, err)
+		}
+		if err = tx.Commit(); err != nil {
+			return fmt.Errorf("commit migration %d_%s: %w", m.version, m.name, err)
+		}
+	}
+	return nil
+}
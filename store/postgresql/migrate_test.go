@@ -0,0 +1,59 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseMigrationName(t *testing.T) {
+	Convey("解析迁移文件名", t, func() {
+		version, name, err := parseMigrationName("0012_add_index.sql")
+		So(err, ShouldBeNil)
+		So(version, ShouldEqual, 12)
+		So(name, ShouldEqual, "add_index")
+	})
+	Convey("非法的迁移文件名", t, func() {
+		for _, file := range []string{"add_index.sql", "0001.sql", "0001_.sql", "0000_init.sql", "0001_init.txt"} {
+			_, _, err := parseMigrationName(file)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestLoadMigrations(t *testing.T) {
+	Convey("内置的迁移按版本号递增", t, func() {
+		migrations, err := loadMigrations()
+		So(err, ShouldBeNil)
+		So(len(migrations), ShouldBeGreaterThan, 0)
+		for i, m := range migrations {
+			So(m.sql, ShouldNotBeEmpty)
+			if i > 0 {
+				So(m.version, ShouldBeGreaterThan, migrations[i-1].version)
+			}
+		}
+	})
+	Convey("转换 timestamptz 的迁移不在事务中执行，每张表单独提交", t, func() {
+		migrations, err := loadMigrations()
+		So(err, ShouldBeNil)
+		for _, m := range migrations {
+			So(m.noTx, ShouldEqual, m.version == 6)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+-- 将 scripts/polaris_server_v1.17.2.sql 初始化的表结构升级到当前版本
+-- 所有语句仅在旧版本的表或列存在时生效，新建的数据库以及已是当前版本的数据库执行后不发生变化
+
+DO $$
+DECLARE
+    item text[];
+BEGIN
+    -- l5 相关表的列名由大小写混合改为小写，与 store 中不带引号的列名保持一致
+    FOREACH item SLICE 1 IN ARRAY ARRAY[
+        ['t_ip_config', 'Fip', 'fip'], ['t_ip_config', 'FareaId', 'fareaid'],
+        ['t_ip_config', 'FcityId', 'fcityid'], ['t_ip_config', 'FidcId', 'fidcid'],
+        ['t_ip_config', 'Fflag', 'fflag'], ['t_ip_config', 'Fstamp', 'fstamp'],
+        ['t_ip_config', 'Fflow', 'fflow'],
+        ['t_policy', 'FmodId', 'fmodid'], ['t_policy', 'Fdiv', 'fdiv'], ['t_policy', 'Fmod', 'fmod'],
+        ['t_policy', 'Fflag', 'fflag'], ['t_policy', 'Fstamp', 'fstamp'], ['t_policy', 'Fflow', 'fflow'],
+        ['t_route', 'Fip', 'fip'], ['t_route', 'FmodId', 'fmodid'], ['t_route', 'FcmdId', 'fcmdid'],
+        ['t_route', 'Fsetid', 'fsetid'], ['t_route', 'Fflag', 'fflag'], ['t_route', 'Fstamp', 'fstamp'],
+        ['t_route', 'Fflow', 'fflow'],
+        ['t_section', 'Fmodid', 'fmodid'], ['t_section', 'Ffrom', 'ffrom'], ['t_section', 'Fto', 'fto'],
+        ['t_section', 'Fxid', 'fxid'], ['t_section', 'Fflag', 'fflag'], ['t_section', 'Fstamp', 'fstamp'],
+        ['t_section', 'Fflow', 'fflow']
+    ] LOOP
+        IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema()
+                AND table_name = item[1] AND column_name = item[2]) THEN
+            EXECUTE format('ALTER TABLE %I RENAME COLUMN %I TO %I', item[1], item[2], item[3]);
+        END IF;
+    END LOOP;
+
+    -- 索引统一使用 idx_ 前缀
+    FOREACH item SLICE 1 IN ARRAY ARRAY[
+        ['instance', 'host', 'idx_host'], ['instance', 'mtime', 'idx_mtime'],
+        ['instance', 'service_id', 'idx_service_id'], ['instance_metadata', 'mkey', 'idx_mkey'],
+        ['leader_election', 'version', 'idx_version']
+    ] LOOP
+        IF EXISTS (SELECT 1 FROM pg_indexes WHERE schemaname = current_schema()
+                AND tablename = item[1] AND indexname = item[2]) THEN
+            IF to_regclass(quote_ident(item[3])) IS NULL THEN
+                EXECUTE format('ALTER INDEX %I RENAME TO %I', item[2], item[3]);
+            ELSE
+                EXECUTE format('DROP INDEX %I', item[2]);
+            END IF;
+        END IF;
+    END LOOP;
+
+    -- 配置相关表的主键改为由序列生成
+    FOREACH item SLICE 1 IN ARRAY ARRAY[
+        ['config_file', 'config_file_id_seq'], ['config_file_group', 'config_file_group_id_seq'],
+        ['config_file_release', 'config_file_release_id_seq'],
+        ['config_file_release_history', 'config_file_release_history_id_seq'],
+        ['config_file_tag', 'config_file_tag_id_seq'], ['config_file_template', 'config_file_template_id_seq']
+    ] LOOP
+        IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema()
+                AND table_name = item[1] AND column_name = 'id' AND column_default IS NULL
+                AND is_identity = 'NO') THEN
+            EXECUTE format('CREATE SEQUENCE IF NOT EXISTS %I OWNED BY %I.id', item[2], item[1]);
+            EXECUTE format('ALTER TABLE %I ALTER COLUMN id SET DEFAULT nextval(%L::regclass)', item[1], item[2]);
+            EXECUTE format('SELECT setval(%L, COALESCE(MAX(id), 0) + 1, false) FROM %I', item[2], item[1]);
+        END IF;
+    END LOOP;
+
+    -- auth_strategy 新增的 default_status 与 default 含义一致
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema()
+            AND table_name = 'auth_strategy' AND column_name = 'default')
+        AND NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema()
+            AND table_name = 'auth_strategy' AND column_name = 'default_status') THEN
+        ALTER TABLE auth_strategy ADD COLUMN default_status int2 NOT NULL DEFAULT 0;
+        UPDATE auth_strategy SET default_status = "default";
+    END IF;
+END $$;
+
+ALTER TABLE IF EXISTS "auth_strategy" ADD COLUMN IF NOT EXISTS "source" varchar(32) NOT NULL DEFAULT '';
+ALTER TABLE IF EXISTS "auth_strategy" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "circuitbreaker_rule" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "circuitbreaker_rule_v2" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "fault_detect_rule" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "ratelimit_config" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "routing_config_v2" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "namespace" ADD COLUMN IF NOT EXISTS "service_export_to" text;
+ALTER TABLE IF EXISTS "namespace" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "service" ADD COLUMN IF NOT EXISTS "export_to" text;
+ALTER TABLE IF EXISTS "user" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "user_group" ADD COLUMN IF NOT EXISTS "metadata" text;
+ALTER TABLE IF EXISTS "config_file_release" ADD COLUMN IF NOT EXISTS "release_type" varchar(25) NOT NULL DEFAULT '';
+ALTER TABLE IF EXISTS "config_file_release" ALTER COLUMN "version" TYPE int8;
@@ -0,0 +1,30 @@
+-- config_file_release_history 按 create_time、client_stat 按 mtime 转换为范围分区表
+-- 原有的表重命名为 <表名>_legacy 并作为覆盖到迁移次日零点的分区挂载，不需要拷贝数据，之后的分区由 store 按配置自动创建
+
+ALTER TABLE IF EXISTS "client_stat" ADD COLUMN IF NOT EXISTS "mtime" timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+DO $$
+DECLARE
+    item text[];
+    bound text := to_char(date_trunc('day', LOCALTIMESTAMP) + interval '1 day', 'YYYY-MM-DD HH24:MI:SS');
+BEGIN
+    FOREACH item SLICE 1 IN ARRAY ARRAY[
+        ['config_file_release_history', 'create_time', 'id, create_time'],
+        ['client_stat', 'mtime', 'client_id, target, port, mtime']
+    ] LOOP
+        IF EXISTS (SELECT 1 FROM pg_class WHERE oid = to_regclass(quote_ident(item[1])) AND relkind = 'r') THEN
+            EXECUTE format('ALTER TABLE %I RENAME TO %I', item[1], item[1] || '_legacy');
+            EXECUTE format('ALTER TABLE %I RENAME CONSTRAINT %I TO %I',
+                item[1] || '_legacy', item[1] || '_pkey', item[1] || '_legacy_pkey');
+            EXECUTE format('CREATE TABLE %I (LIKE %I INCLUDING DEFAULTS INCLUDING COMMENTS, PRIMARY KEY (%s)) '
+                'PARTITION BY RANGE (%I)', item[1], item[1] || '_legacy', item[3], item[2]);
+            EXECUTE format('ALTER TABLE %I ATTACH PARTITION %I FOR VALUES FROM (MINVALUE) TO (%L)',
+                item[1], item[1] || '_legacy', bound);
+        END IF;
+    END LOOP;
+
+    -- 序列归属于分区表，避免删除过期的 legacy 分区时序列被一并删除
+    IF to_regclass('config_file_release_history_id_seq') IS NOT NULL THEN
+        ALTER SEQUENCE config_file_release_history_id_seq OWNED BY config_file_release_history.id;
+    END IF;
+END $$;
@@ -0,0 +1,36 @@
+-- cache 增量查询涉及的表在数据变更后通过 pg_notify 通知 polaris_change 频道
+-- 消息内容为 JSON：schema、table、op 以及触发器参数指定的主键列的值
+
+CREATE OR REPLACE FUNCTION polaris_notify_change() RETURNS trigger AS $$
+DECLARE
+    rec jsonb;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        rec := to_jsonb(OLD);
+    ELSE
+        rec := to_jsonb(NEW);
+    END IF;
+    PERFORM pg_notify('polaris_change', json_build_object('schema', TG_TABLE_SCHEMA, 'table', TG_TABLE_NAME,
+        'op', TG_OP, 'key', rec ->> TG_ARGV[0])::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    item text[];
+BEGIN
+    FOREACH item SLICE 1 IN ARRAY ARRAY[
+        ['namespace', 'name'], ['service', 'id'], ['instance', 'id'], ['client', 'id'],
+        ['routing_config', 'id'], ['routing_config_v2', 'id'], ['ratelimit_config', 'id'],
+        ['circuitbreaker_rule_v2', 'id'], ['fault_detect_rule', 'id'], ['service_contract', 'id'],
+        ['config_file_group', 'id'], ['config_file_release', 'id'], ['gray_resource', 'name'],
+        ['user', 'id'], ['user_group', 'id'], ['auth_strategy', 'id']
+    ] LOOP
+        IF to_regclass(quote_ident(item[1])) IS NOT NULL THEN
+            EXECUTE format('DROP TRIGGER IF EXISTS %I ON %I', item[1] || '_notify_change', item[1]);
+            EXECUTE format('CREATE TRIGGER %I AFTER INSERT OR UPDATE OR DELETE ON %I '
+                'FOR EACH ROW EXECUTE FUNCTION polaris_notify_change(%L)', item[1] || '_notify_change', item[1], item[2]);
+        END IF;
+    END LOOP;
+END $$;
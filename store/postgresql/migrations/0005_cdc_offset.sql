@@ -0,0 +1,11 @@
+-- 逻辑复制消费方已确认的位置，重启后从该位置继续消费
+
+CREATE TABLE IF NOT EXISTS "cdc_offset" (
+  "slot_name" varchar(64) COLLATE "pg_catalog"."default" NOT NULL,
+  "confirmed_lsn" pg_lsn NOT NULL,
+  "mtime" timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT "cdc_offset_pkey" PRIMARY KEY ("slot_name")
+)
+;
+COMMENT ON COLUMN "cdc_offset"."slot_name" IS 'logical replication slot name';
+COMMENT ON COLUMN "cdc_offset"."confirmed_lsn" IS 'lsn of the last transaction handled by the consumer';
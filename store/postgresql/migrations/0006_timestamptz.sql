@@ -0,0 +1,37 @@
+-- migrate:no-transaction
+-- 将当前 schema 下的 timestamp 列转换为 timestamptz，已有的数据按照执行迁移的连接的 TimeZone 解释，
+-- store 会将连接的 TimeZone 设置为 timezone 配置，需要与历史数据写入时使用的时区一致
+-- 分区表的分区键不允许修改类型，保持 timestamp，按照会话时区的墙上时间读写
+-- 会话时区不是 UTC 时转换需要重写表，期间持有该表的 ACCESS EXCLUSIVE 锁，读写均会阻塞
+-- 每张表在独立的事务中转换并立即提交，同一时间只锁住一张表；中断后重新执行只会转换剩余的 timestamp 列
+
+DO $$
+DECLARE
+    tbl record;
+BEGIN
+    FOR tbl IN
+        SELECT c.relname AS table_name,
+            string_agg(format('ALTER COLUMN %I TYPE %s', a.attname,
+                CASE WHEN a.atttypmod >= 0 THEN format('timestamptz(%s)', a.atttypmod) ELSE 'timestamptz' END),
+                ', ' ORDER BY a.attnum) AS alters
+        FROM pg_attribute a
+        JOIN pg_class c ON c.oid = a.attrelid
+        JOIN pg_namespace n ON n.oid = c.relnamespace
+        WHERE n.nspname = current_schema()
+          AND c.relkind IN ('r', 'p')
+          AND NOT c.relispartition
+          AND a.attnum > 0
+          AND NOT a.attisdropped
+          AND a.atttypid = 'timestamp'::regtype
+          AND NOT EXISTS (
+              SELECT 1 FROM pg_partitioned_table pt
+              WHERE pt.partrelid = c.oid AND a.attnum = ANY (pt.partattrs::int2[]))
+        GROUP BY c.relname
+        ORDER BY c.relname
+    LOOP
+        -- 同一张表的所有列在一条语句中转换，只重写一次表
+        EXECUTE format('ALTER TABLE %I %s', tbl.table_name, tbl.alters);
+        COMMIT;
+    END LOOP;
+END;
+$$;
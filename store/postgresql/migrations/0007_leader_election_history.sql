@@ -0,0 +1,23 @@
+-- leader 选举的角色变更历史，节点每次变更为 leader 或 follower 时写入一行
+
+CREATE TABLE IF NOT EXISTS "leader_election_history" (
+  "id" bigserial NOT NULL,
+  "elect_key" varchar(128) COLLATE "pg_catalog"."default" NOT NULL,
+  "host" varchar(128) COLLATE "pg_catalog"."default" NOT NULL,
+  "role" varchar(16) COLLATE "pg_catalog"."default" NOT NULL,
+  "leader" varchar(128) COLLATE "pg_catalog"."default" NOT NULL,
+  "fencing_token" int8 NOT NULL DEFAULT 0,
+  "ctime" timestamptz(6) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT "leader_election_history_pkey" PRIMARY KEY ("id")
+)
+;
+COMMENT ON COLUMN "leader_election_history"."elect_key" IS '选举键';
+COMMENT ON COLUMN "leader_election_history"."host" IS '发生角色变更的节点';
+COMMENT ON COLUMN "leader_election_history"."role" IS '变更后的角色，leader 或 follower';
+COMMENT ON COLUMN "leader_election_history"."leader" IS '变更后该节点看到的 leader';
+COMMENT ON COLUMN "leader_election_history"."fencing_token" IS '变更时节点持有的 fencing token';
+COMMENT ON COLUMN "leader_election_history"."ctime" IS '变更时间';
+
+CREATE INDEX IF NOT EXISTS "idx_leader_election_history_key_ctime" ON "leader_election_history" USING btree (
+  "elect_key", "ctime"
+);
@@ -0,0 +1,46 @@
+-- 变更通知改为语句级触发器，一条语句只发送一次 NOTIFY，只刷新了 mtime 的更新（如心跳续约）不发送
+-- 触发器不再由迁移创建，store 在 Initialize 时按 changeFeed.enable 创建或者删除
+-- 消息内容为 JSON：schema、table、op 以及变更记录的主键列表，变更的记录超过 64 条时 keys 为空，订阅方按 mtime 增量拉取
+
+CREATE OR REPLACE FUNCTION polaris_notify_change_stmt() RETURNS trigger AS $$
+DECLARE
+    keys text[];
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        EXECUTE format('SELECT array_agg(k) FROM (SELECT %I::text AS k FROM polaris_new LIMIT 65) t', TG_ARGV[0])
+            INTO keys;
+    ELSIF TG_OP = 'DELETE' THEN
+        EXECUTE format('SELECT array_agg(k) FROM (SELECT %I::text AS k FROM polaris_old LIMIT 65) t', TG_ARGV[0])
+            INTO keys;
+    ELSE
+        EXECUTE format('SELECT array_agg(k) FROM (SELECT n.%1$I::text AS k FROM polaris_new n '
+            'JOIN polaris_old o ON o.%1$I = n.%1$I '
+            'WHERE to_jsonb(n) - ''mtime'' IS DISTINCT FROM to_jsonb(o) - ''mtime'' LIMIT 65) t', TG_ARGV[0])
+            INTO keys;
+    END IF;
+    IF keys IS NULL THEN
+        RETURN NULL;
+    END IF;
+    IF array_length(keys, 1) > 64 THEN
+        keys := NULL;
+    END IF;
+    PERFORM pg_notify('polaris_change', json_build_object('schema', TG_TABLE_SCHEMA, 'table', TG_TABLE_NAME,
+        'op', TG_OP, 'keys', keys)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    trig record;
+BEGIN
+    FOR trig IN
+        SELECT c.relname AS table_name, t.tgname AS trigger_name
+        FROM pg_trigger t
+        JOIN pg_class c ON c.oid = t.tgrelid
+        JOIN pg_namespace n ON n.oid = c.relnamespace
+        WHERE n.nspname = current_schema() AND NOT t.tgisinternal AND t.tgname LIKE '%\_notify\_change'
+    LOOP
+        EXECUTE format('DROP TRIGGER IF EXISTS %I ON %I', trig.trigger_name, trig.table_name);
+    END LOOP;
+END $$;
@@ -0,0 +1,16 @@
+-- 为 0003 转换的分区表创建 DEFAULT 分区，预创建的分区没有及时补齐时写入落到默认分区而不是直接失败
+-- store 维护分区时会把默认分区中落在新分区范围内的数据移动到新创建的分区
+-- client_stat 的主键为 (client_id, target, port, mtime)，数据库不再保证 (client_id, target, port) 唯一，
+-- store 写入客户端统计时总是先在同一个事务中删除该客户端已有的统计，并且与 client 表的写入处于同一个事务
+
+DO $$
+DECLARE
+    item text;
+BEGIN
+    FOREACH item IN ARRAY ARRAY['config_file_release_history', 'client_stat'] LOOP
+        IF EXISTS (SELECT 1 FROM pg_partitioned_table
+                   WHERE partrelid = to_regclass(quote_ident(item)) AND partdefid = 0) THEN
+            EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF %I DEFAULT', item || '_default', item);
+        END IF;
+    END LOOP;
+END $$;
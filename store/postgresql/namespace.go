@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -32,7 +33,7 @@ import (
 // namespaceStore 实现了NamespaceStore
 type namespaceStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // AddNamespace 添加命名空间
@@ -159,13 +160,25 @@ func (ns *namespaceStore) GetNamespaces(filter map[string][]string, offset,
 // GetMoreNamespaces 根据mtime获取命名空间
 func (ns *namespaceStore) GetMoreNamespaces(mtime time.Time) ([]*model.Namespace, error) {
 	str := genNamespaceSelectSQL() + " WHERE mtime >= $1"
-	rows, err := ns.slave.Query(str, mtime) // PostgreSQL accepts time.Time directly
+	since := watermarks.since(mtime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	// PostgreSQL accepts time.Time directly
+	rows, err := ns.slave.cacheReader().QueryContext(ctx, "namespaceStore.GetMoreNamespaces", str, since)
 	if err != nil {
 		log.Errorf("[Store][database] get more namespace query err: %s", err.Error())
 		return nil, err
 	}
 
-	return namespaceFetchRows(rows)
+	namespaces, err := namespaceFetchRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	// 命名空间没有首次拉取的标记，cache 重建时传入的 mtime 会回退，此时不去重
+	return dedupSlice(watermarks, "namespace", since, false, namespaces,
+		func(namespace *model.Namespace) (string, string) {
+			return namespace.Name, rowVersion(namespace.ModifyTime)
+		}), nil
 }
 
 // getNamespacesCount 根据相关条件查询对应命名空间数目
@@ -175,7 +188,7 @@ func (ns *namespaceStore) getNamespacesCount(filter map[string][]string) (uint32
 
 	var count uint32
 
-	err := ns.master.QueryRow(str, args...).Scan(&count)
+	err := ns.master.QueryRow("namespaceStore.getNamespacesCount", str, args...).Scan(&count)
 	switch {
 	case err == sql.ErrNoRows:
 		log.Errorf("[Store][database] no row with this namespace filter")
@@ -195,7 +208,7 @@ func (ns *namespaceStore) getNamespaces(filter map[string][]string, offset,
 	order := &Order{"mtime", "desc"}
 	str, args := genNamespaceWhereSQLAndArgs(str, filter, order, offset, limit)
 
-	rows, err := ns.master.Query(str, args...)
+	rows, err := ns.master.Query("namespaceStore.getNamespaces", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get namespaces by filter query err: %s", err.Error())
 		return nil, err
@@ -211,7 +224,7 @@ func (ns *namespaceStore) getNamespace(name string) (*model.Namespace, error) {
 	}
 
 	str := genNamespaceSelectSQL() + " where name = $1"
-	rows, err := ns.master.Query(str, name)
+	rows, err := ns.master.Query("namespaceStore.getNamespace", str, name)
 	if err != nil {
 		log.Errorf("[Store][database] get namespace query err: %s", err.Error())
 		return nil, err
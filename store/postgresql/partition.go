@@ -0,0 +1,414 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+const (
+	// PartitionDay 按天分区
+	PartitionDay = "day"
+	// PartitionMonth 按月分区
+	PartitionMonth = "month"
+	// DefaultPartitionCheckInterval 分区维护的默认间隔
+	DefaultPartitionCheckInterval = time.Hour
+
+	// partitionLockSql 多个节点同时维护分区时只有一个节点生效
+	partitionLockSql = "SELECT pg_advisory_xact_lock(hashtext(current_schema() || '.partition'))"
+	// partitionTimeLayout 分区边界的时间格式
+	partitionTimeLayout = "2006-01-02 15:04:05"
+)
+
+// partitionBoundRegex 解析 pg_get_expr(relpartbound) 的结果，如 FOR VALUES FROM ('2024-01-01 00:00:00') TO (MAXVALUE)
+var partitionBoundRegex = regexp.MustCompile(`^FOR VALUES FROM \((.+)\) TO \((.+)\)$`)
+
+// partitionPolicy 单张分区表的维护策略
+type partitionPolicy struct {
+	// interval 分区粒度，day 或者 month
+	interval string
+	// premake 除当前周期外预先创建的分区个数
+	premake int
+	// retention 分区的保留时长，分区的上界早于 now - retention 时整个分区被删除，为0时不删除
+	retention time.Duration
+}
+
+// partitionConfig 分区维护配置
+type partitionConfig struct {
+	checkInterval time.Duration
+	tables        map[string]*partitionPolicy
+}
+
+// defaultPartitionPolicies 由 0003 迁移转换为分区表的表及其默认策略
+// 默认不删除分区，config_file_release_history 由 CleanConfigFileReleaseHistory 按北极星的配置清理
+var defaultPartitionPolicies = map[string]partitionPolicy{
+	"config_file_release_history": {interval: PartitionMonth, premake: 3},
+	"client_stat":                 {interval: PartitionDay, premake: 7},
+}
+
+// parsePartitionConfig 解析分区维护配置，未配置的表使用默认策略
+func parsePartitionConfig(opts interface{}) (*partitionConfig, error) {
+	cfg := &partitionConfig{
+		checkInterval: DefaultPartitionCheckInterval,
+		tables:        make(map[string]*partitionPolicy, len(defaultPartitionPolicies)),
+	}
+	for table, policy := range defaultPartitionPolicies {
+		item := policy
+		cfg.tables[table] = &item
+	}
+	if opts == nil {
+		return cfg, nil
+	}
+	obj, ok := opts.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config Plugin %s:partition type must be map", STORENAME)
+	}
+	if val, ok := obj["checkInterval"]; ok {
+		d, err := time.ParseDuration(fmt.Sprintf("%v", val))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("config Plugin %s:partition.checkInterval is invalid duration: %v", STORENAME, val)
+		}
+		cfg.checkInterval = d
+	}
+	if val, ok := obj["tables"]; ok {
+		tables, ok := val.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config Plugin %s:partition.tables type must be map", STORENAME)
+		}
+		for key, val := range tables {
+			table := fmt.Sprintf("%v", key)
+			policy, ok := cfg.tables[table]
+			if !ok {
+				return nil, fmt.Errorf("config Plugin %s:partition.tables.%s is not a partitioned table",
+					STORENAME, table)
+			}
+			if err := parsePartitionPolicy(val, policy); err != nil {
+				return nil, fmt.Errorf("config Plugin %s:partition.tables.%s %s", STORENAME, table, err.Error())
+			}
+		}
+	}
+	return cfg, nil
+}
+
+func parsePartitionPolicy(opts interface{}, policy *partitionPolicy) error {
+	obj, ok := opts.(map[interface{}]interface{})
+	if !ok {
+		return fmt.Errorf("type must be map")
+	}
+	if val, ok := obj["interval"]; ok {
+		interval, _ := val.(string)
+		if interval != PartitionDay && interval != PartitionMonth {
+			return fmt.Errorf("interval must be %s or %s", PartitionDay, PartitionMonth)
+		}
+		policy.interval = interval
+	}
+	if val, ok := obj["premake"]; ok {
+		premake, ok := val.(int)
+		if !ok || premake < 0 {
+			return fmt.Errorf("premake must be a non-negative integer")
+		}
+		policy.premake = premake
+	}
+	if val, ok := obj["retention"]; ok {
+		d, err := time.ParseDuration(fmt.Sprintf("%v", val))
+		if err != nil || d < 0 {
+			return fmt.Errorf("retention is invalid duration: %v", val)
+		}
+		policy.retention = d
+	}
+	return nil
+}
+
+// periodStart 时间所在周期的起始时间
+func (p *partitionPolicy) periodStart(t time.Time) time.Time {
+	if p.interval == PartitionMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// nextPeriod 下一个周期的起始时间
+func (p *partitionPolicy) nextPeriod(t time.Time) time.Time {
+	start := p.periodStart(t)
+	if p.interval == PartitionMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// partitionRange 单个分区的范围 [from, to)，from 为零值表示 MINVALUE，to 为零值表示 MAXVALUE
+type partitionRange struct {
+	name string
+	from time.Time
+	to   time.Time
+}
+
+// parsePartitionBound 解析分区边界，默认分区返回 false
+func parsePartitionBound(name, bound string) (partitionRange, bool, error) {
+	if bound == "DEFAULT" {
+		return partitionRange{}, false, nil
+	}
+	match := partitionBoundRegex.FindStringSubmatch(bound)
+	if match == nil {
+		return partitionRange{}, false, fmt.Errorf("partition %s has unsupported bound %s", name, bound)
+	}
+	ret := partitionRange{name: name}
+	for i, target := range []*time.Time{&ret.from, &ret.to} {
+		val := match[i+1]
+		if val == "MINVALUE" || val == "MAXVALUE" {
+			continue
+		}
+		t, err := time.Parse(partitionTimeLayout, trimQuote(val))
+		if err != nil {
+			return partitionRange{}, false, fmt.Errorf("partition %s has unsupported bound %s", name, bound)
+		}
+		*target = t
+	}
+	return ret, true, nil
+}
+
+// trimQuote 去掉分区边界两侧的单引号，忽略小数秒
+func trimQuote(val string) string {
+	if len(val) >= 2 && val[0] == '\'' && val[len(val)-1] == '\'' {
+		val = val[1 : len(val)-1]
+	}
+	if len(val) > len(partitionTimeLayout) {
+		val = val[:len(partitionTimeLayout)]
+	}
+	return val
+}
+
+// planPartitions 计算需要创建以及需要删除的分区
+// 新的分区从已有分区的最大上界开始创建，保证分区之间连续，直到覆盖当前周期之后的 premake 个周期
+func planPartitions(table string, now time.Time, existing []partitionRange,
+	policy *partitionPolicy) ([]partitionRange, []string) {
+	var (
+		creates []partitionRange
+		drops   []string
+		upper   time.Time
+		// covered 已有覆盖到 MAXVALUE 的分区，无需再创建
+		covered bool
+	)
+	for _, item := range existing {
+		if item.to.IsZero() {
+			covered = true
+		} else if item.to.After(upper) {
+			upper = item.to
+		}
+	}
+	if !covered {
+		start := policy.periodStart(now)
+		from := upper
+		if from.IsZero() {
+			from = start
+		}
+		if from.Before(start) {
+			// 长时间未维护时，用一个分区补齐已有分区到当前周期之间的空缺
+			creates = append(creates, newPartitionRange(table, from, start))
+			from = start
+		}
+		target := start
+		for i := 0; i <= policy.premake; i++ {
+			target = policy.nextPeriod(target)
+		}
+		for from.Before(target) {
+			to := policy.nextPeriod(from)
+			creates = append(creates, newPartitionRange(table, from, to))
+			from = to
+		}
+	}
+	if policy.retention > 0 {
+		cutoff := now.Add(-policy.retention)
+		for _, item := range existing {
+			if !item.to.IsZero() && !item.to.After(cutoff) {
+				drops = append(drops, item.name)
+			}
+		}
+		sort.Strings(drops)
+	}
+	return creates, drops
+}
+
+// newPartitionRange 分区以起始时间命名，如 client_stat_p20240101
+func newPartitionRange(table string, from, to time.Time) partitionRange {
+	return partitionRange{name: fmt.Sprintf("%s_p%s", table, from.Format("20060102")), from: from, to: to}
+}
+
+// partitionTable 分区表的分区键以及已有的分区
+type partitionTable struct {
+	// column 分区键
+	column string
+	// defaultName 默认分区的表名，没有默认分区时为空
+	defaultName string
+	ranges      []partitionRange
+}
+
+// listPartitions 查询分区表的分区键以及所有分区，表不存在或者不是分区表时返回 nil
+func listPartitions(tx *BaseTx, table string) (*partitionTable, error) {
+	ret := &partitionTable{}
+	err := tx.QueryRow("SELECT a.attname FROM pg_partitioned_table pt JOIN pg_attribute a "+
+		"ON a.attrelid = pt.partrelid AND a.attnum = pt.partattrs[0] WHERE pt.partrelid = to_regclass($1)",
+		quoteIdentifier(table)).Scan(&ret.column)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	rows, err := tx.Query("SELECT c.relname, pg_get_expr(c.relpartbound, c.oid) FROM pg_inherits i "+
+		"JOIN pg_class c ON c.oid = i.inhrelid WHERE i.inhparent = to_regclass($1)", quoteIdentifier(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, bound string
+		if err := rows.Scan(&name, &bound); err != nil {
+			return nil, err
+		}
+		item, ok, err := parsePartitionBound(name, bound)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ret.ranges = append(ret.ranges, item)
+		} else {
+			ret.defaultName = name
+		}
+	}
+	return ret, rows.Err()
+}
+
+// createPartition 创建分区，存在默认分区时先创建独立的表，
+// 把默认分区中落在新分区范围内的数据移动过来之后再挂载，否则默认分区中的数据会导致创建分区失败
+func createPartition(tx *BaseTx, table string, partitions *partitionTable, item partitionRange) error {
+	from, to := item.from.Format(partitionTimeLayout), item.to.Format(partitionTimeLayout)
+	log.Infof("[Store][database] create partition %s for [%s, %s)", item.name, from, to)
+	if partitions.defaultName == "" {
+		_, err := tx.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+			quoteIdentifier(item.name), quoteIdentifier(table), from, to))
+		return err
+	}
+	column, name := quoteIdentifier(partitions.column), quoteIdentifier(item.name)
+	if _, err := tx.Exec(fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS)",
+		name, quoteIdentifier(table))); err != nil {
+		return err
+	}
+	result, err := tx.Exec(fmt.Sprintf("WITH moved AS (DELETE FROM %s WHERE %s >= '%s' AND %s < '%s' RETURNING *) "+
+		"INSERT INTO %s SELECT * FROM moved", quoteIdentifier(partitions.defaultName), column, from, column, to, name))
+	if err != nil {
+		return err
+	}
+	if moved, _ := result.RowsAffected(); moved > 0 {
+		log.Warnf("[Store][database] move %d rows from default partition %s to %s",
+			moved, partitions.defaultName, item.name)
+	}
+	_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')",
+		quoteIdentifier(table), name, from, to))
+	return err
+}
+
+// maintainPartitions 为所有分区表创建未来的分区并删除过期的分区
+func (b *BaseDB) maintainPartitions(ctx context.Context, tables map[string]*partitionPolicy) error {
+	return b.processWithTransactionContext(ctx, opAdminCleanup, "maintainPartitions", func(tx *BaseTx) error {
+		if _, err := tx.Exec(partitionLockSql); err != nil {
+			return err
+		}
+		// 分区边界与数据库会话时区下写入的时间保持一致
+		var now time.Time
+		if err := tx.QueryRow("SELECT LOCALTIMESTAMP").Scan(&now); err != nil {
+			return err
+		}
+		now = wallClock(now)
+		for table, policy := range tables {
+			partitions, err := listPartitions(tx, table)
+			if err != nil {
+				return err
+			}
+			if partitions == nil {
+				continue
+			}
+			creates, drops := planPartitions(table, now, partitions.ranges, policy)
+			for _, item := range creates {
+				if err := createPartition(tx, table, partitions, item); err != nil {
+					return err
+				}
+			}
+			for _, name := range drops {
+				log.Infof("[Store][database] drop expired partition %s of %s", name, table)
+				if _, err := tx.Exec("DROP TABLE IF EXISTS " + quoteIdentifier(name)); err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+// dropPartitionsBefore 删除上界不晚于 endTime 的分区，返回表是否为分区表
+func (b *BaseDB) dropPartitionsBefore(ctx context.Context, table string, endTime time.Time) (bool, error) {
+	partitioned := false
+	err := b.processWithTransactionContext(ctx, opAdminCleanup, "dropPartitions", func(tx *BaseTx) error {
+		if _, err := tx.Exec(partitionLockSql); err != nil {
+			return err
+		}
+		partitions, err := listPartitions(tx, table)
+		if err != nil || partitions == nil {
+			return err
+		}
+		partitioned = true
+		for _, item := range partitions.ranges {
+			if item.to.IsZero() || item.to.After(endTime) {
+				continue
+			}
+			log.Infof("[Store][database] drop expired partition %s of %s", item.name, table)
+			if _, err := tx.Exec("DROP TABLE IF EXISTS " + quoteIdentifier(item.name)); err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+	return partitioned, err
+}
+
+// runPartitionMaintainer 定时维护分区
+func runPartitionMaintainer(ctx context.Context, db *BaseDB, cfg *partitionConfig) {
+	ticker := time.NewTicker(cfg.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := db.maintainPartitions(ctx, cfg.tables); err != nil {
+				log.Errorf("[Store][database] maintain partitions err: %s", err.Error())
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// wallClock 丢弃时区信息，按墙上时间比较 timestamp 列与分区边界
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
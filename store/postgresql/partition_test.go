@@ -0,0 +1,179 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParsePartitionBound(t *testing.T) {
+	Convey("解析分区边界", t, func() {
+		item, ok, err := parsePartitionBound("client_stat_p20241001",
+			"FOR VALUES FROM ('2024-10-01 00:00:00') TO ('2024-10-02 00:00:00')")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(item.from, ShouldEqual, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC))
+		So(item.to, ShouldEqual, time.Date(2024, 10, 2, 0, 0, 0, 0, time.UTC))
+
+		item, ok, err = parsePartitionBound("client_stat_legacy",
+			"FOR VALUES FROM (MINVALUE) TO ('2024-10-01 00:00:00.5')")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(item.from.IsZero(), ShouldBeTrue)
+		So(item.to, ShouldEqual, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC))
+
+		_, ok, err = parsePartitionBound("client_stat_default", "DEFAULT")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+
+		_, _, err = parsePartitionBound("client_stat_list", "FOR VALUES IN ('a')")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestPlanPartitions(t *testing.T) {
+	now := time.Date(2024, 10, 15, 8, 30, 0, 0, time.UTC)
+	day := time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC)
+	Convey("从 legacy 分区的上界开始创建连续的分区", t, func() {
+		existing := []partitionRange{{name: "client_stat_legacy", to: day}}
+		creates, drops := planPartitions("client_stat", now, existing, &partitionPolicy{interval: PartitionDay, premake: 2})
+		So(drops, ShouldBeEmpty)
+		So(len(creates), ShouldEqual, 2)
+		So(creates[0].name, ShouldEqual, "client_stat_p20241016")
+		So(creates[0].from, ShouldEqual, day)
+		So(creates[1].to, ShouldEqual, time.Date(2024, 10, 18, 0, 0, 0, 0, time.UTC))
+	})
+	Convey("按月分区时第一个分区补齐到下个月", t, func() {
+		existing := []partitionRange{{name: "config_file_release_history_legacy", to: day}}
+		creates, _ := planPartitions("config_file_release_history", now, existing,
+			&partitionPolicy{interval: PartitionMonth, premake: 1})
+		So(len(creates), ShouldEqual, 2)
+		So(creates[0].from, ShouldEqual, day)
+		So(creates[0].to, ShouldEqual, time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC))
+		So(creates[1].to, ShouldEqual, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC))
+	})
+	Convey("长时间未维护时补齐空缺", t, func() {
+		existing := []partitionRange{{name: "client_stat_p20240101", from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}}
+		creates, _ := planPartitions("client_stat", now, existing, &partitionPolicy{interval: PartitionDay})
+		So(len(creates), ShouldEqual, 2)
+		So(creates[0].from, ShouldEqual, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+		So(creates[0].to, ShouldEqual, time.Date(2024, 10, 15, 0, 0, 0, 0, time.UTC))
+		So(creates[1].to, ShouldEqual, day)
+	})
+	Convey("已覆盖到 MAXVALUE 时不再创建", t, func() {
+		existing := []partitionRange{{name: "client_stat_all"}}
+		creates, _ := planPartitions("client_stat", now, existing, &partitionPolicy{interval: PartitionDay, premake: 3})
+		So(creates, ShouldBeEmpty)
+	})
+	Convey("删除超过保留时长的分区", t, func() {
+		existing := []partitionRange{
+			{name: "client_stat_legacy", to: time.Date(2024, 10, 10, 0, 0, 0, 0, time.UTC)},
+			{name: "client_stat_p20241010", from: time.Date(2024, 10, 10, 0, 0, 0, 0, time.UTC),
+				to: time.Date(2024, 10, 11, 0, 0, 0, 0, time.UTC)},
+			{name: "client_stat_p20241015", from: time.Date(2024, 10, 15, 0, 0, 0, 0, time.UTC), to: day},
+		}
+		_, drops := planPartitions("client_stat", now, existing,
+			&partitionPolicy{interval: PartitionDay, retention: 4 * 24 * time.Hour})
+		So(drops, ShouldResemble, []string{"client_stat_legacy", "client_stat_p20241010"})
+	})
+}
+
+func TestParsePartitionConfig(t *testing.T) {
+	Convey("未配置时使用默认策略", t, func() {
+		cfg, err := parsePartitionConfig(nil)
+		So(err, ShouldBeNil)
+		So(cfg.checkInterval, ShouldEqual, DefaultPartitionCheckInterval)
+		So(cfg.tables["client_stat"].interval, ShouldEqual, PartitionDay)
+		So(cfg.tables["config_file_release_history"].retention, ShouldEqual, 0)
+	})
+	Convey("覆盖单张表的策略", t, func() {
+		cfg, err := parsePartitionConfig(map[interface{}]interface{}{
+			"checkInterval": "10m",
+			"tables": map[interface{}]interface{}{
+				"client_stat": map[interface{}]interface{}{"interval": "month", "premake": 1, "retention": "720h"},
+			},
+		})
+		So(err, ShouldBeNil)
+		So(cfg.checkInterval, ShouldEqual, 10*time.Minute)
+		So(*cfg.tables["client_stat"], ShouldResemble,
+			partitionPolicy{interval: PartitionMonth, premake: 1, retention: 720 * time.Hour})
+		So(defaultPartitionPolicies["client_stat"].interval, ShouldEqual, PartitionDay)
+	})
+	Convey("非法配置", t, func() {
+		for _, opts := range []interface{}{
+			"1h",
+			map[interface{}]interface{}{"checkInterval": "0s"},
+			map[interface{}]interface{}{"tables": map[interface{}]interface{}{"instance": map[interface{}]interface{}{}}},
+			map[interface{}]interface{}{"tables": map[interface{}]interface{}{
+				"client_stat": map[interface{}]interface{}{"interval": "week"}}},
+			map[interface{}]interface{}{"tables": map[interface{}]interface{}{
+				"client_stat": map[interface{}]interface{}{"premake": -1}}},
+			map[interface{}]interface{}{"tables": map[interface{}]interface{}{
+				"client_stat": map[interface{}]interface{}{"retention": "7d"}}},
+		} {
+			_, err := parsePartitionConfig(opts)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestPartitionDefault(t *testing.T) {
+	obj := requireDB(t)
+	table := "test_partition_default"
+	for _, str := range []string{
+		"DROP TABLE IF EXISTS " + table,
+		"CREATE TABLE " + table + " (id int NOT NULL, mtime timestamp NOT NULL, PRIMARY KEY (id, mtime)) " +
+			"PARTITION BY RANGE (mtime)",
+		"CREATE TABLE " + table + "_default PARTITION OF " + table + " DEFAULT",
+		"INSERT INTO " + table + " (id, mtime) VALUES (1, LOCALTIMESTAMP)",
+	} {
+		if _, err := obj.master.Exec("TestPartitionDefault", str); err != nil {
+			t.Fatalf("prepare %s err: %s", table, err.Error())
+		}
+	}
+	defer func() { _, _ = obj.master.Exec("TestPartitionDefault", "DROP TABLE IF EXISTS "+table) }()
+
+	Convey("创建分区时移出默认分区中的数据", t, func() {
+		err := obj.master.maintainPartitions(context.Background(),
+			map[string]*partitionPolicy{table: {interval: PartitionDay, premake: 1}})
+		So(err, ShouldBeNil)
+
+		var partition string
+		err = obj.master.QueryRow("TestPartitionDefault",
+			"SELECT tableoid::regclass::text FROM "+table+" WHERE id = 1").Scan(&partition)
+		So(err, ShouldBeNil)
+		So(partition, ShouldStartWith, table+"_p")
+
+		tx, err := obj.master.Begin("TestPartitionDefault")
+		So(err, ShouldBeNil)
+		defer func() { _ = tx.Rollback() }()
+		partitions, err := listPartitions(tx, table)
+		So(err, ShouldBeNil)
+		So(partitions.column, ShouldEqual, "mtime")
+		So(partitions.defaultName, ShouldEqual, table+"_default")
+		So(partitions.ranges, ShouldHaveLength, 2)
+
+		missing, err := listPartitions(tx, "test_partition_missing")
+		So(err, ShouldBeNil)
+		So(missing, ShouldBeNil)
+	})
+}
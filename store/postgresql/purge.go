@@ -0,0 +1,343 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/polarismesh/polaris/common/model"
+)
+
+const (
+	// DefaultPurgeInterval 清理软删除数据的默认间隔
+	DefaultPurgeInterval = 10 * time.Minute
+	// DefaultPurgeRetention 软删除数据默认的保留时长，需要大于 cache 增量拉取的间隔，保证删除事件已经被感知
+	DefaultPurgeRetention = 7 * 24 * time.Hour
+	// DefaultPurgeBatchSize 每个事务默认清理的数据条数
+	DefaultPurgeBatchSize = 1000
+)
+
+// PurgeTarget 可以被清理的软删除表，flag = 1 且修改时间早于保留时长的数据会连同子表中关联的数据一起删除
+// AppendOnly 的历史表没有 flag 列，时间早于保留时长的数据全部删除
+type PurgeTarget struct {
+	// Table 软删除的主表
+	Table string
+	// Key 主表的主键列
+	Key string
+	// TimeColumn 主表的修改时间列
+	TimeColumn string
+	// Children 引用主表数据的子表，与主表的数据在同一条语句中删除
+	Children []PurgeChild
+	// AppendOnly 只追加的历史表，没有 flag 列
+	AppendOnly bool
+}
+
+// PurgeChild 引用主表数据的子表，Column 为引用主表主键的列
+// 子表不是通过单独一列引用主表主键时使用 On 设置关联条件，设置 On 后忽略 Column
+type PurgeChild struct {
+	Table  string
+	Column string
+	// On 子表与本批删除的主表数据的关联条件，child 为子表的别名，purged 为本批删除的主表数据，包含主表的所有列
+	On string
+}
+
+var (
+	purgeTargetsLock sync.RWMutex
+	// purgeTargets 已注册的清理对象，key 为主表表名
+	purgeTargets = map[string]*PurgeTarget{}
+)
+
+func init() {
+	for _, target := range []PurgeTarget{
+		{Table: "namespace", Key: "name", TimeColumn: "mtime"},
+		{Table: "service", Key: "id", TimeColumn: "mtime", Children: []PurgeChild{
+			{Table: "service_metadata", Column: "id"},
+			{Table: "circuitbreaker_rule_relation", Column: "service_id"},
+			{Table: "ratelimit_revision", Column: "service_id"},
+		}},
+		{Table: "instance", Key: "id", TimeColumn: "mtime", Children: []PurgeChild{
+			{Table: "instance_metadata", Column: "id"},
+			{Table: "health_check", Column: "id"},
+		}},
+		{Table: "client", Key: "id", TimeColumn: "mtime", Children: []PurgeChild{
+			{Table: "client_stat", Column: "client_id"},
+		}},
+		{Table: "routing_config", Key: "id", TimeColumn: "mtime"},
+		{Table: "routing_config_v2", Key: "id", TimeColumn: "mtime"},
+		{Table: "ratelimit_config", Key: "id", TimeColumn: "mtime"},
+		{Table: "circuitbreaker_rule_v2", Key: "id", TimeColumn: "mtime"},
+		{Table: "fault_detect_rule", Key: "id", TimeColumn: "mtime"},
+		{Table: "service_contract", Key: "id", TimeColumn: "mtime", Children: []PurgeChild{
+			{Table: "service_contract_detail", Column: "contract_id"},
+		}},
+		{Table: "config_file", Key: "id", TimeColumn: "modify_time", Children: []PurgeChild{
+			// 同名的配置文件重新创建后标签属于新的配置文件，不能删除
+			{Table: "config_file_tag", On: `child."namespace" = purged."namespace" AND ` +
+				`child."group" = purged."group" AND child.file_name = purged.name AND NOT EXISTS (` +
+				`SELECT 1 FROM config_file f WHERE f."namespace" = child."namespace" AND ` +
+				`f."group" = child."group" AND f.name = child.file_name AND f.flag = 0)`},
+		}},
+		{Table: "config_file_group", Key: "id", TimeColumn: "modify_time"},
+		{Table: "config_file_release", Key: "id", TimeColumn: "modify_time"},
+		{Table: "gray_resource", Key: "name", TimeColumn: "modify_time"},
+		{Table: "user", Key: "id", TimeColumn: "mtime", Children: []PurgeChild{
+			{Table: "user_group_relation", Column: "user_id"},
+			{Table: "auth_principal", On: fmt.Sprintf("child.principal_id = purged.id AND child.principal_role = %d",
+				model.PrincipalUser)},
+		}},
+		{Table: "user_group", Key: "id", TimeColumn: "mtime", Children: []PurgeChild{
+			{Table: "user_group_relation", Column: "group_id"},
+			{Table: "auth_principal", On: fmt.Sprintf("child.principal_id = purged.id AND child.principal_role = %d",
+				model.PrincipalGroup)},
+		}},
+		{Table: "leader_election_history", Key: "id", TimeColumn: "ctime", AppendOnly: true},
+		{Table: "auth_strategy", Key: "id", TimeColumn: "mtime", Children: []PurgeChild{
+			{Table: "auth_principal", Column: "strategy_id"},
+			{Table: "auth_strategy_resource", Column: "strategy_id"},
+			{Table: "auth_strategy_function", Column: "strategy_id"},
+			{Table: "auth_strategy_label", Column: "strategy_id"},
+		}},
+	} {
+		if err := RegisterPurgeTarget(target); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterPurgeTarget 注册清理对象，需要在 Initialize 之前调用，同名的主表会被覆盖
+func RegisterPurgeTarget(target PurgeTarget) error {
+	if target.Table == "" || target.Key == "" || target.TimeColumn == "" {
+		return fmt.Errorf("purge target table, key and time column are required")
+	}
+	for _, child := range target.Children {
+		if child.Table == "" || (child.Column == "" && child.On == "") {
+			return fmt.Errorf("purge target %s child table and column or join condition are required", target.Table)
+		}
+	}
+	purgeTargetsLock.Lock()
+	defer purgeTargetsLock.Unlock()
+	item := target
+	item.Children = append([]PurgeChild(nil), target.Children...)
+	purgeTargets[target.Table] = &item
+	return nil
+}
+
+func getPurgeTarget(table string) (*PurgeTarget, bool) {
+	purgeTargetsLock.RLock()
+	defer purgeTargetsLock.RUnlock()
+	target, ok := purgeTargets[table]
+	return target, ok
+}
+
+// purgeSql 删除一批软删除数据以及子表中关联数据的语句，返回删除的主表数据条数
+// $1 为保留时长（秒），$2 为批大小，被其他事务锁定的数据留到下一批处理
+// 存在按关联条件删除的子表时 purged 返回主表的所有列
+func (t *PurgeTarget) purgeSql() string {
+	table, key := quoteIdentifier(t.Table), quoteIdentifier(t.Key)
+	returning := key
+	for _, child := range t.Children {
+		if child.On != "" {
+			returning = "*"
+		}
+	}
+	deleted, recheck := "flag = 1 AND ", " AND flag = 1"
+	if t.AppendOnly {
+		deleted, recheck = "", ""
+	}
+	ctes := []string{fmt.Sprintf("purged AS (DELETE FROM %s WHERE %s IN (SELECT %s FROM %s WHERE %s"+
+		"%s < now() - make_interval(secs => $1) ORDER BY %s LIMIT $2 FOR UPDATE SKIP LOCKED)%s "+
+		"RETURNING %s)", table, key, key, table, deleted, quoteIdentifier(t.TimeColumn),
+		quoteIdentifier(t.TimeColumn), recheck, returning)}
+	for i, child := range t.Children {
+		if child.On != "" {
+			ctes = append(ctes, fmt.Sprintf("child%d AS (DELETE FROM %s AS child USING purged WHERE %s)",
+				i, quoteIdentifier(child.Table), child.On))
+			continue
+		}
+		ctes = append(ctes, fmt.Sprintf("child%d AS (DELETE FROM %s WHERE %s IN (SELECT %s FROM purged))",
+			i, quoteIdentifier(child.Table), quoteIdentifier(child.Column), key))
+	}
+	return "WITH " + strings.Join(ctes, ", ") + " SELECT count(*) FROM purged"
+}
+
+// purgePolicy 单张表的清理策略
+type purgePolicy struct {
+	// enable 是否清理该表
+	enable bool
+	// retention 软删除数据的保留时长
+	retention time.Duration
+	// batchSize 每个事务清理的数据条数
+	batchSize int
+}
+
+// purgeConfig 软删除数据清理配置
+type purgeConfig struct {
+	enable   bool
+	interval time.Duration
+	tables   map[string]*purgePolicy
+}
+
+// parsePurgeConfig 解析 purge 配置，未单独配置的表使用全局的 retention 以及 batchSize
+func parsePurgeConfig(opts interface{}) (*purgeConfig, error) {
+	cfg := &purgeConfig{interval: DefaultPurgeInterval, tables: map[string]*purgePolicy{}}
+	defaults := purgePolicy{enable: true, retention: DefaultPurgeRetention, batchSize: DefaultPurgeBatchSize}
+	var tables map[interface{}]interface{}
+	if opts != nil {
+		obj, ok := opts.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config Plugin %s:purge type must be map", STORENAME)
+		}
+		cfg.enable, _ = obj["enable"].(bool)
+		if val, ok := obj["interval"]; ok {
+			d, err := time.ParseDuration(fmt.Sprintf("%v", val))
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("config Plugin %s:purge.interval is invalid duration: %v", STORENAME, val)
+			}
+			cfg.interval = d
+		}
+		if err := parsePurgePolicy(obj, &defaults); err != nil {
+			return nil, fmt.Errorf("config Plugin %s:purge %s", STORENAME, err.Error())
+		}
+		if val, ok := obj["tables"]; ok {
+			if tables, ok = val.(map[interface{}]interface{}); !ok {
+				return nil, fmt.Errorf("config Plugin %s:purge.tables type must be map", STORENAME)
+			}
+		}
+	}
+
+	purgeTargetsLock.RLock()
+	for table := range purgeTargets {
+		policy := defaults
+		cfg.tables[table] = &policy
+	}
+	purgeTargetsLock.RUnlock()
+	for key, val := range tables {
+		table := fmt.Sprintf("%v", key)
+		policy, ok := cfg.tables[table]
+		if !ok {
+			return nil, fmt.Errorf("config Plugin %s:purge.tables.%s is not a registered purge target",
+				STORENAME, table)
+		}
+		obj, ok := val.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config Plugin %s:purge.tables.%s type must be map", STORENAME, table)
+		}
+		if enable, ok := obj["enable"].(bool); ok {
+			policy.enable = enable
+		}
+		if err := parsePurgePolicy(obj, policy); err != nil {
+			return nil, fmt.Errorf("config Plugin %s:purge.tables.%s %s", STORENAME, table, err.Error())
+		}
+	}
+	return cfg, nil
+}
+
+func parsePurgePolicy(obj map[interface{}]interface{}, policy *purgePolicy) error {
+	if val, ok := obj["retention"]; ok {
+		d, err := time.ParseDuration(fmt.Sprintf("%v", val))
+		if err != nil || d <= 0 {
+			return fmt.Errorf("retention is invalid duration: %v", val)
+		}
+		policy.retention = d
+	}
+	if val, ok := obj["batchSize"]; ok {
+		size, ok := val.(int)
+		if !ok || size <= 0 {
+			return fmt.Errorf("batchSize must be a positive integer")
+		}
+		policy.batchSize = size
+	}
+	return nil
+}
+
+// purgeBatch 在一个事务中清理一批软删除数据，返回删除的主表数据条数
+func (b *BaseDB) purgeBatch(ctx context.Context, target *PurgeTarget, retention time.Duration,
+	batchSize int) (int64, error) {
+	var count int64
+	err := b.processWithTransactionContext(ctx, opAdminCleanup, "purge_"+target.Table, func(tx *BaseTx) error {
+		if err := tx.QueryRow(target.purgeSql(), int64(retention.Seconds()), batchSize).Scan(&count); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+	reportPurge(target.Table, count, err)
+	return count, err
+}
+
+// purgeTable 分批清理一张表中过期的软删除数据，直到不足一批或者 ctx 结束，返回删除的主表数据条数
+func (b *BaseDB) purgeTable(ctx context.Context, target *PurgeTarget, policy *purgePolicy) (int64, error) {
+	var total int64
+	for {
+		count, err := b.purgeBatch(ctx, target, policy.retention, policy.batchSize)
+		total += count
+		if err != nil {
+			return total, err
+		}
+		if count < int64(policy.batchSize) || ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+	}
+}
+
+// purgeAll 按配置清理所有已注册的表，单张表失败不影响其他表
+func (b *BaseDB) purgeAll(ctx context.Context, cfg *purgeConfig) {
+	tables := make([]string, 0, len(cfg.tables))
+	for table, policy := range cfg.tables {
+		if policy.enable {
+			tables = append(tables, table)
+		}
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		target, ok := getPurgeTarget(table)
+		if !ok {
+			continue
+		}
+		start := time.Now()
+		total, err := b.purgeTable(ctx, target, cfg.tables[table])
+		if err != nil {
+			log.Errorf("[Store][database] purge soft deleted %s err: %s", table, err.Error())
+		}
+		if total > 0 {
+			log.Infof("[Store][database] purge soft deleted %s, rows: %d, cost: %s", table, total, time.Since(start))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// runPurger 定时清理软删除数据，ctx 结束后退出
+func runPurger(ctx context.Context, db *BaseDB, cfg *purgeConfig) {
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			db.purgeAll(ctx, cfg)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,165 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPurgeSql(t *testing.T) {
+	Convey("主表与子表在同一条语句中删除", t, func() {
+		target := &PurgeTarget{Table: "user", Key: "id", TimeColumn: "mtime", Children: []PurgeChild{
+			{Table: "user_group_relation", Column: "user_id"},
+		}}
+		So(target.purgeSql(), ShouldEqual, `WITH purged AS (DELETE FROM "user" WHERE "id" IN `+
+			`(SELECT "id" FROM "user" WHERE flag = 1 AND "mtime" < now() - make_interval(secs => $1) `+
+			`ORDER BY "mtime" LIMIT $2 FOR UPDATE SKIP LOCKED) AND flag = 1 RETURNING "id"), `+
+			`child0 AS (DELETE FROM "user_group_relation" WHERE "user_id" IN (SELECT "id" FROM purged)) `+
+			`SELECT count(*) FROM purged`)
+	})
+	Convey("按关联条件删除子表", t, func() {
+		user, ok := getPurgeTarget("user")
+		So(ok, ShouldBeTrue)
+		So(user.purgeSql(), ShouldEqual, `WITH purged AS (DELETE FROM "user" WHERE "id" IN `+
+			`(SELECT "id" FROM "user" WHERE flag = 1 AND "mtime" < now() - make_interval(secs => $1) `+
+			`ORDER BY "mtime" LIMIT $2 FOR UPDATE SKIP LOCKED) AND flag = 1 RETURNING *), `+
+			`child0 AS (DELETE FROM "user_group_relation" WHERE "user_id" IN (SELECT "id" FROM purged)), `+
+			`child1 AS (DELETE FROM "auth_principal" AS child USING purged `+
+			`WHERE child.principal_id = purged.id AND child.principal_role = 1) `+
+			`SELECT count(*) FROM purged`)
+
+		group, ok := getPurgeTarget("user_group")
+		So(ok, ShouldBeTrue)
+		So(group.purgeSql(), ShouldContainSubstring, `child1 AS (DELETE FROM "auth_principal" AS child USING purged `+
+			`WHERE child.principal_id = purged.id AND child.principal_role = 2)`)
+
+		file, ok := getPurgeTarget("config_file")
+		So(ok, ShouldBeTrue)
+		So(file.purgeSql(), ShouldEqual, `WITH purged AS (DELETE FROM "config_file" WHERE "id" IN `+
+			`(SELECT "id" FROM "config_file" WHERE flag = 1 `+
+			`AND "modify_time" < now() - make_interval(secs => $1) `+
+			`ORDER BY "modify_time" LIMIT $2 FOR UPDATE SKIP LOCKED) AND flag = 1 RETURNING *), `+
+			`child0 AS (DELETE FROM "config_file_tag" AS child USING purged `+
+			`WHERE child."namespace" = purged."namespace" AND child."group" = purged."group" `+
+			`AND child.file_name = purged.name AND NOT EXISTS (SELECT 1 FROM config_file f `+
+			`WHERE f."namespace" = child."namespace" AND f."group" = child."group" `+
+			`AND f.name = child.file_name AND f.flag = 0)) `+
+			`SELECT count(*) FROM purged`)
+	})
+	Convey("只追加的历史表按时间删除", t, func() {
+		history, ok := getPurgeTarget("leader_election_history")
+		So(ok, ShouldBeTrue)
+		So(history.purgeSql(), ShouldEqual, `WITH purged AS (DELETE FROM "leader_election_history" WHERE "id" IN `+
+			`(SELECT "id" FROM "leader_election_history" WHERE "ctime" < now() - make_interval(secs => $1) `+
+			`ORDER BY "ctime" LIMIT $2 FOR UPDATE SKIP LOCKED) RETURNING "id") SELECT count(*) FROM purged`)
+	})
+	Convey("内置的清理对象", t, func() {
+		service, ok := getPurgeTarget("service")
+		So(ok, ShouldBeTrue)
+		So(service.Children, ShouldContain, PurgeChild{Table: "service_metadata", Column: "id"})
+		So(service.Children, ShouldContain, PurgeChild{Table: "circuitbreaker_rule_relation", Column: "service_id"})
+		instance, ok := getPurgeTarget("instance")
+		So(ok, ShouldBeTrue)
+		So(instance.Children, ShouldHaveLength, 2)
+	})
+}
+
+func TestRegisterPurgeTarget(t *testing.T) {
+	Convey("缺少必填字段", t, func() {
+		So(RegisterPurgeTarget(PurgeTarget{Table: "lane_group", Key: "id"}), ShouldNotBeNil)
+		So(RegisterPurgeTarget(PurgeTarget{Table: "lane_group", Key: "id", TimeColumn: "mtime",
+			Children: []PurgeChild{{Table: "lane_rule"}}}), ShouldNotBeNil)
+		So(RegisterPurgeTarget(PurgeTarget{Table: "lane_group", Key: "id", TimeColumn: "mtime",
+			Children: []PurgeChild{{On: "child.group_id = purged.id"}}}), ShouldNotBeNil)
+		_, ok := getPurgeTarget("lane_group")
+		So(ok, ShouldBeFalse)
+	})
+	Convey("注册后可以单独配置", t, func() {
+		So(RegisterPurgeTarget(PurgeTarget{Table: "test_purge", Key: "id", TimeColumn: "mtime"}), ShouldBeNil)
+		defer func() {
+			purgeTargetsLock.Lock()
+			delete(purgeTargets, "test_purge")
+			purgeTargetsLock.Unlock()
+		}()
+		cfg, err := parsePurgeConfig(map[interface{}]interface{}{
+			"tables": map[interface{}]interface{}{"test_purge": map[interface{}]interface{}{"batchSize": 10}},
+		})
+		So(err, ShouldBeNil)
+		So(cfg.tables["test_purge"].batchSize, ShouldEqual, 10)
+	})
+}
+
+func TestParsePurgeConfig(t *testing.T) {
+	Convey("默认关闭，所有表使用默认策略", t, func() {
+		cfg, err := parsePurgeConfig(nil)
+		So(err, ShouldBeNil)
+		So(cfg.enable, ShouldBeFalse)
+		So(cfg.interval, ShouldEqual, DefaultPurgeInterval)
+		So(*cfg.tables["instance"], ShouldResemble, purgePolicy{enable: true, retention: DefaultPurgeRetention,
+			batchSize: DefaultPurgeBatchSize})
+	})
+	Convey("全局策略以及单表策略", t, func() {
+		cfg, err := parsePurgeConfig(map[interface{}]interface{}{
+			"enable": true, "interval": "1m", "retention": "24h", "batchSize": 200,
+			"tables": map[interface{}]interface{}{
+				"instance": map[interface{}]interface{}{"retention": "1h", "batchSize": 50},
+				"user":     map[interface{}]interface{}{"enable": false},
+			},
+		})
+		So(err, ShouldBeNil)
+		So(cfg.enable, ShouldBeTrue)
+		So(cfg.interval, ShouldEqual, time.Minute)
+		So(*cfg.tables["instance"], ShouldResemble, purgePolicy{enable: true, retention: time.Hour, batchSize: 50})
+		So(*cfg.tables["service"], ShouldResemble, purgePolicy{enable: true, retention: 24 * time.Hour,
+			batchSize: 200})
+		So(cfg.tables["user"].enable, ShouldBeFalse)
+	})
+	Convey("非法配置", t, func() {
+		for _, opts := range []interface{}{
+			"purge",
+			map[interface{}]interface{}{"interval": "0s"},
+			map[interface{}]interface{}{"retention": "abc"},
+			map[interface{}]interface{}{"batchSize": 0},
+			map[interface{}]interface{}{"tables": map[interface{}]interface{}{"unknown": map[interface{}]interface{}{}}},
+			map[interface{}]interface{}{"tables": map[interface{}]interface{}{"instance": "1h"}},
+		} {
+			_, err := parsePurgeConfig(opts)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestPurgeAll(t *testing.T) {
+	obj := initConf()
+	if obj.master == nil {
+		return
+	}
+	cfg, err := parsePurgeConfig(map[interface{}]interface{}{"retention": "1h", "batchSize": 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj.master.purgeAll(context.Background(), cfg)
+	target, _ := getPurgeTarget("instance")
+	rows, err := obj.master.purgeTable(context.Background(), target, cfg.tables["instance"])
+	fmt.Printf("rows: %d, err: %+v\n", rows, err)
+}
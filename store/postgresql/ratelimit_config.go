@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -34,7 +35,7 @@ var _ store.RateLimitStore = (*rateLimitStore)(nil)
 // rateLimitStore RateLimitStore的实现
 type rateLimitStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateRateLimit 新建限流规则
@@ -59,7 +60,7 @@ func limitToEtimeStr(limit *model.RateLimit) string {
 
 // createRateLimit
 func (rls *rateLimitStore) createRateLimit(limit *model.RateLimit) error {
-	tx, err := rls.master.Begin()
+	tx, err := rls.master.Begin("rateLimitStore.createRateLimit")
 	if err != nil {
 		log.Errorf("[Store][database] create rate limit(%+v) begin tx err: %s", limit, err.Error())
 		return err
@@ -124,7 +125,7 @@ func (rls *rateLimitStore) EnableRateLimit(limit *model.RateLimit) error {
 
 // enableRateLimit
 func (rls *rateLimitStore) enableRateLimit(limit *model.RateLimit) error {
-	tx, err := rls.master.Begin()
+	tx, err := rls.master.Begin("rateLimitStore.enableRateLimit")
 	if err != nil {
 		log.Errorf("[Store][database] update rate limit(%+v) begin tx err: %s", limit, err.Error())
 		return err
@@ -161,7 +162,7 @@ func (rls *rateLimitStore) enableRateLimit(limit *model.RateLimit) error {
 
 // updateRateLimit
 func (rls *rateLimitStore) updateRateLimit(limit *model.RateLimit) error {
-	tx, err := rls.master.Begin()
+	tx, err := rls.master.Begin("rateLimitStore.updateRateLimit")
 	if err != nil {
 		log.Errorf("[Store][database] update rate limit(%+v) begin tx err: %s", limit, err.Error())
 		return err
@@ -211,7 +212,7 @@ func (rls *rateLimitStore) DeleteRateLimit(limit *model.RateLimit) error {
 
 // deleteRateLimit
 func (rls *rateLimitStore) deleteRateLimit(limit *model.RateLimit) error {
-	tx, err := rls.master.Begin()
+	tx, err := rls.master.Begin("rateLimitStore.deleteRateLimit")
 	if err != nil {
 		log.Errorf("[Store][database] delete rate limit(%+v) begin tx err: %s", limit, err.Error())
 		return err
@@ -248,7 +249,7 @@ func (rls *rateLimitStore) GetRateLimitWithID(id string) (*model.RateLimit, erro
 	str := "select id, name, disable, service_id, method, labels, priority, " +
 		"rule, revision, flag, ctime, mtime, etime " +
 		"from ratelimit_config where id = $1 and flag = 0"
-	rows, err := rls.master.Query(str, id)
+	rows, err := rls.master.Query("rateLimitStore.GetRateLimitWithID", str, id)
 	if err != nil {
 		log.Errorf("[Store][database] query rate limit with id(%s) err: %s", id, err.Error())
 		return nil, err
@@ -295,11 +296,14 @@ func (rls *rateLimitStore) GetRateLimitsForCache(mtime time.Time,
 	firstUpdate bool) ([]*model.RateLimit, error) {
 	str := "select id, name, disable, ratelimit_config.service_id, method, labels, priority, " +
 		"rule, revision, flag, ratelimit_config.ctime, ratelimit_config.mtime, ratelimit_config.etime " +
-		"from ratelimit_config where ratelimit_config.mtime > $1"
+		"from ratelimit_config where ratelimit_config.mtime >= $1"
 	if firstUpdate {
 		str += " and flag != 1"
 	}
-	rows, err := rls.slave.Query(str, mtime)
+	since := watermarks.since(mtime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := rls.slave.cacheReader().QueryContext(ctx, "rateLimitStore.GetRateLimitsForCache", str, since)
 	if err != nil {
 		log.Errorf("[Store][database] query rate limits with mtime err: %s", err.Error())
 		return nil, err
@@ -308,7 +312,10 @@ func (rls *rateLimitStore) GetRateLimitsForCache(mtime time.Time,
 	if err != nil {
 		return nil, err
 	}
-	return rateLimits, nil
+	return dedupSlice(watermarks, "ratelimit_config", since, firstUpdate, rateLimits,
+		func(rule *model.RateLimit) (string, string) {
+			return rule.ID, rowVersion(rule.ModifyTime, rule.Revision)
+		}), nil
 }
 
 // fetchRateLimitCacheRows 读取限流数据以及最新版本号
@@ -389,7 +396,7 @@ func (rls *rateLimitStore) getBriefRateLimits(
 	args = append(args, limit, offset)
 	str = str + queryStr + fmt.Sprintf(` order by ratelimit_config.mtime desc limit $%d offset $%d`, index, index+1)
 
-	rows, err := rls.master.Query(str, args...)
+	rows, err := rls.master.Query("rateLimitStore.getBriefRateLimits", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] query rate limits err: %s", err.Error())
 		return nil, err
@@ -445,7 +452,7 @@ func (rls *rateLimitStore) getExpandRateLimits(
 	args = append(args, limit, offset)
 	str = str + queryStr + fmt.Sprintf(` order by ratelimit_config.mtime desc limit $%d offset $%d`, index, index+1)
 
-	rows, err := rls.master.Query(str, args...)
+	rows, err := rls.master.Query("rateLimitStore.getExpandRateLimits", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] query rate limits err: %s", err.Error())
 		return nil, err
@@ -497,7 +504,7 @@ func (rls *rateLimitStore) getExpandRateLimitsCount(filter map[string]string) (u
 	queryStr, args, _ := genFilterRateLimitSQL(filter, 1)
 	str = str + queryStr
 	var total uint32
-	err := rls.master.QueryRow(str, args...).Scan(&total)
+	err := rls.master.QueryRow("rateLimitStore.getExpandRateLimitsCount", str, args...).Scan(&total)
 	switch {
 	case err == sql.ErrNoRows:
 		return 0, nil
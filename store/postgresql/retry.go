@@ -0,0 +1,211 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+const (
+	// serializationFailureCode SQLSTATE serialization_failure
+	serializationFailureCode = "40001"
+	// deadlockDetectedCode SQLSTATE deadlock_detected
+	deadlockDetectedCode = "40P01"
+	// adminShutdownCode SQLSTATE admin_shutdown
+	adminShutdownCode = "57P01"
+	// connectionExceptionClass SQLSTATE class 08, connection exception
+	connectionExceptionClass = "08"
+
+	serializationFailureMsg = "could not serialize access"
+	deadlockDetectedMsg     = "deadlock detected"
+)
+
+// db抛出的异常，需要重试的字符串组
+// 调用方大多会通过 store.Error 包装错误，丢失了驱动的错误类型，此时只能按照错误信息匹配
+var errMsg = []string{
+	serializationFailureMsg,
+	deadlockDetectedMsg,
+	"bad connection",
+	"terminating connection due to administrator command",
+}
+
+// retryConfig 重试策略
+type retryConfig struct {
+	// maxAttempts 最多执行的次数，包含第一次执行
+	maxAttempts int
+	// baseDelay 第一次重试前的等待时间，之后每次翻倍
+	baseDelay time.Duration
+	// maxDelay 单次等待时间的上限
+	maxDelay time.Duration
+	// deadline 单次调用包含重试在内的总耗时上限，为0时不限制
+	deadline time.Duration
+}
+
+var (
+	defaultRetryConfig = retryConfig{
+		maxAttempts: 10,
+		baseDelay:   5 * time.Millisecond,
+		maxDelay:    time.Second,
+		deadline:    10 * time.Second,
+	}
+	// retryCfg 当前生效的重试策略，在 Initialize 时设置
+	retryCfg = defaultRetryConfig
+)
+
+// parseRetryConfig 解析重试策略配置，未配置的字段使用默认值
+func parseRetryConfig(opts interface{}) (retryConfig, error) {
+	cfg := defaultRetryConfig
+	if opts == nil {
+		return cfg, nil
+	}
+	obj, ok := opts.(map[interface{}]interface{})
+	if !ok {
+		return cfg, fmt.Errorf("config Plugin %s:retry type must be map", STORENAME)
+	}
+	if maxAttempts, _ := obj["maxAttempts"].(int); maxAttempts > 0 {
+		cfg.maxAttempts = maxAttempts
+	}
+	durations := map[string]*time.Duration{
+		"baseDelay": &cfg.baseDelay,
+		"maxDelay":  &cfg.maxDelay,
+		"deadline":  &cfg.deadline,
+	}
+	for key, target := range durations {
+		val, ok := obj[key]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(fmt.Sprintf("%v", val))
+		if err != nil || d < 0 {
+			return cfg, fmt.Errorf("config Plugin %s:retry.%s is invalid duration: %v", STORENAME, key, val)
+		}
+		*target = d
+	}
+	if cfg.baseDelay > cfg.maxDelay {
+		return cfg, fmt.Errorf("config Plugin %s:retry.baseDelay must not be greater than maxDelay", STORENAME)
+	}
+	return cfg, nil
+}
+
+// backoff 第 attempt 次失败后的等待时间，指数退避并在 [delay/2, delay] 之间随机抖动
+func (c retryConfig) backoff(attempt int) time.Duration {
+	delay := c.maxDelay
+	if shift := attempt - 1; shift < 32 {
+		if d := c.baseDelay << uint(shift); d > 0 && d < c.maxDelay {
+			delay = d
+		}
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// isRetryableError 根据 SQLSTATE 判断错误是否可以重试
+// 40001 序列化失败、40P01 死锁、08xxx 连接异常以及 57P01 管理员关闭连接
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	if code := sqlState(err); code != "" {
+		switch code {
+		case serializationFailureCode, deadlockDetectedCode, adminShutdownCode:
+			return true
+		}
+		return strings.HasPrefix(code, connectionExceptionClass)
+	}
+	msg := err.Error()
+	for _, item := range errMsg {
+		if strings.Contains(msg, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExecRetryable 事务外的写语句是否可以重试
+// 连接异常时语句可能已经在数据库执行，重试会重复写入，因此只重试驱动保证语句未发送的 driver.ErrBadConn，
+// 以及数据库已经回滚该语句的序列化失败和死锁
+func isExecRetryable(err error) bool {
+	return errors.Is(err, driver.ErrBadConn) || isTxConflict(err)
+}
+
+// isTxConflict 事务是否因为并发冲突被中止，重新执行整个事务即可
+func isTxConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	if code := sqlState(err); code != "" {
+		return code == serializationFailureCode || code == deadlockDetectedCode
+	}
+	msg := err.Error()
+	return strings.Contains(msg, serializationFailureMsg) || strings.Contains(msg, deadlockDetectedMsg)
+}
+
+// isSerializationFailure 是否为 REPEATABLE READ/SERIALIZABLE 隔离级别下的序列化失败
+func isSerializationFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if code := sqlState(err); code != "" {
+		return code == serializationFailureCode
+	}
+	return strings.Contains(err.Error(), serializationFailureMsg)
+}
+
+// retryWithPolicy 按照当前的重试策略执行 handle，retryable 判断错误是否需要重试
+func retryWithPolicy(label string, retryable func(err error) bool, handle func() error) error {
+	cfg := retryCfg
+	start := time.Now()
+	for i := 1; ; i++ {
+		err := handle()
+		if err == nil || !retryable(err) {
+			return err
+		}
+		if i >= cfg.maxAttempts {
+			log.Errorf("[Store][database][%s] get error msg: %s. Give up after %d attempts", label, err.Error(), i)
+			return err
+		}
+		delay := cfg.backoff(i)
+		if cfg.deadline > 0 && time.Since(start)+delay > cfg.deadline {
+			log.Errorf("[Store][database][%s] get error msg: %s. Give up for exceeding deadline %s",
+				label, err.Error(), cfg.deadline)
+			return err
+		}
+		log.Warnf("[Store][database][%s] get error msg: %s. Repeated doing(%d) after %s",
+			label, err.Error(), i, delay)
+		time.Sleep(delay)
+	}
+}
+
+// Retry 重试主函数
+// 可重试的错误按照指数退避等待后重试，直到达到最大执行次数或者总耗时上限
+func Retry(label string, handle func() error) {
+	_ = retryWithPolicy(label, isRetryableError, handle)
+}
+
+// RetryTransaction 事务重试
+func RetryTransaction(label string, handle func() error) error {
+	return retryWithPolicy(label, isRetryableError, handle)
+}
@@ -0,0 +1,169 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"github.com/polarismesh/polaris/store"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	Convey("按照SQLSTATE判断是否重试", t, func() {
+		retryable := []string{"40001", "40P01", "57P01", "08000", "08003", "08006", "08001", "08004"}
+		for _, code := range retryable {
+			So(isRetryableError(&pq.Error{Code: pq.ErrorCode(code)}), ShouldBeTrue)
+			So(isRetryableError(fmt.Errorf("wrap: %w", &pq.Error{Code: pq.ErrorCode(code)})), ShouldBeTrue)
+		}
+		notRetryable := []string{"23505", "23503", "42P01", "42703", "57014", "22001", "40002"}
+		for _, code := range notRetryable {
+			So(isRetryableError(&pq.Error{Code: pq.ErrorCode(code)}), ShouldBeFalse)
+		}
+	})
+	Convey("pgx 驱动按照SQLSTATE判断是否重试", t, func() {
+		So(isRetryableError(&pgconn.PgError{Code: "40P01"}), ShouldBeTrue)
+		So(isRetryableError(fmt.Errorf("wrap: %w", &pgconn.PgError{Code: "08006"})), ShouldBeTrue)
+		So(isRetryableError(&pgconn.PgError{Code: "23505"}), ShouldBeFalse)
+		So(isTxConflict(&pgconn.PgError{Code: "40001"}), ShouldBeTrue)
+		So(isSerializationFailure(&pgconn.PgError{Code: "40P01"}), ShouldBeFalse)
+	})
+	Convey("连接失效可以重试", t, func() {
+		So(isRetryableError(driver.ErrBadConn), ShouldBeTrue)
+		So(isRetryableError(fmt.Errorf("exec: %w", driver.ErrBadConn)), ShouldBeTrue)
+	})
+	Convey("被store.Error包装后按照错误信息判断", t, func() {
+		So(isRetryableError(store.Error(&pq.Error{Code: "40P01", Message: "deadlock detected"})), ShouldBeTrue)
+		So(isRetryableError(store.Error(
+			&pq.Error{Code: "40001", Message: "could not serialize access due to concurrent update"})), ShouldBeTrue)
+		So(isRetryableError(store.Error(&pq.Error{Code: "23505", Message: "duplicate key value"})), ShouldBeFalse)
+	})
+	Convey("其他错误不重试", t, func() {
+		So(isRetryableError(nil), ShouldBeFalse)
+		So(isRetryableError(sql.ErrNoRows), ShouldBeFalse)
+		So(isRetryableError(errors.New("Deadlock")), ShouldBeFalse)
+	})
+}
+
+func TestIsExecRetryable(t *testing.T) {
+	Convey("写语句只在确定未生效时重试", t, func() {
+		So(isExecRetryable(driver.ErrBadConn), ShouldBeTrue)
+		So(isExecRetryable(fmt.Errorf("exec: %w", driver.ErrBadConn)), ShouldBeTrue)
+		So(isExecRetryable(&pq.Error{Code: "40001"}), ShouldBeTrue)
+		So(isExecRetryable(&pgconn.PgError{Code: "40P01"}), ShouldBeTrue)
+	})
+	Convey("连接异常时语句可能已经执行，不重试", t, func() {
+		for _, code := range []string{"08000", "08003", "08006", "57P01"} {
+			So(isExecRetryable(&pq.Error{Code: pq.ErrorCode(code)}), ShouldBeFalse)
+			So(isExecRetryable(&pgconn.PgError{Code: code}), ShouldBeFalse)
+		}
+		So(isExecRetryable(errors.New("unexpected EOF")), ShouldBeFalse)
+	})
+}
+
+func TestIsSerializationFailure(t *testing.T) {
+	Convey("识别序列化失败", t, func() {
+		So(isSerializationFailure(nil), ShouldBeFalse)
+		So(isSerializationFailure(&pq.Error{Code: "40001"}), ShouldBeTrue)
+		So(isSerializationFailure(fmt.Errorf("wrap: %w", &pq.Error{Code: "40001"})), ShouldBeTrue)
+		So(isSerializationFailure(&pq.Error{Code: "23505"}), ShouldBeFalse)
+		So(isSerializationFailure(store.Error(
+			errors.New("pq: could not serialize access due to concurrent update"))), ShouldBeTrue)
+		So(isSerializationFailure(errors.New("other error")), ShouldBeFalse)
+	})
+	Convey("死锁与序列化失败均需要重新执行事务", t, func() {
+		So(isTxConflict(&pq.Error{Code: "40P01"}), ShouldBeTrue)
+		So(isTxConflict(&pq.Error{Code: "40001"}), ShouldBeTrue)
+		So(isTxConflict(&pq.Error{Code: "08006"}), ShouldBeFalse)
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	cfg := retryConfig{maxAttempts: 10, baseDelay: 10 * time.Millisecond, maxDelay: 100 * time.Millisecond}
+	Convey("指数退避并且带有抖动", t, func() {
+		for i := 0; i < 100; i++ {
+			d := cfg.backoff(1)
+			So(d, ShouldBeBetweenOrEqual, 5*time.Millisecond, 10*time.Millisecond)
+			d = cfg.backoff(3)
+			So(d, ShouldBeBetweenOrEqual, 20*time.Millisecond, 40*time.Millisecond)
+		}
+	})
+	Convey("单次等待不超过上限", t, func() {
+		for _, attempt := range []int{5, 10, 64, 1000} {
+			So(cfg.backoff(attempt), ShouldBeLessThanOrEqualTo, cfg.maxDelay)
+		}
+	})
+}
+
+func TestRetryWithPolicy(t *testing.T) {
+	defer func() {
+		retryCfg = defaultRetryConfig
+	}()
+	Convey("达到最大执行次数后放弃", t, func() {
+		retryCfg = retryConfig{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: time.Millisecond}
+		count := 0
+		err := RetryTransaction("max-attempts", func() error {
+			count++
+			return &pq.Error{Code: "40001"}
+		})
+		So(err, ShouldNotBeNil)
+		So(count, ShouldEqual, 3)
+	})
+	Convey("超过总耗时上限后放弃", t, func() {
+		retryCfg = retryConfig{maxAttempts: 100, baseDelay: 20 * time.Millisecond,
+			maxDelay: 20 * time.Millisecond, deadline: 50 * time.Millisecond}
+		count := 0
+		start := time.Now()
+		err := RetryTransaction("deadline", func() error {
+			count++
+			return &pq.Error{Code: "57P01"}
+		})
+		So(err, ShouldNotBeNil)
+		So(count, ShouldBeLessThan, 5)
+		So(time.Since(start), ShouldBeLessThan, 100*time.Millisecond)
+	})
+}
+
+func TestParseRetryConfig(t *testing.T) {
+	Convey("未配置时使用默认值", t, func() {
+		cfg, err := parseRetryConfig(nil)
+		So(err, ShouldBeNil)
+		So(cfg, ShouldResemble, defaultRetryConfig)
+	})
+	Convey("解析重试配置", t, func() {
+		cfg, err := parseRetryConfig(map[interface{}]interface{}{
+			"maxAttempts": 5, "baseDelay": "10ms", "maxDelay": "500ms", "deadline": "3s",
+		})
+		So(err, ShouldBeNil)
+		So(cfg, ShouldResemble, retryConfig{maxAttempts: 5, baseDelay: 10 * time.Millisecond,
+			maxDelay: 500 * time.Millisecond, deadline: 3 * time.Second})
+	})
+	Convey("非法的重试配置", t, func() {
+		_, err := parseRetryConfig(map[interface{}]interface{}{"baseDelay": "abc"})
+		So(err, ShouldNotBeNil)
+		_, err = parseRetryConfig(map[interface{}]interface{}{"baseDelay": "2s", "maxDelay": "1s"})
+		So(err, ShouldNotBeNil)
+	})
+}
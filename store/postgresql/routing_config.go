@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -30,7 +31,7 @@ import (
 // RoutingConfigStore的实现
 type routingConfigStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateRoutingConfig 新建RoutingConfig
@@ -161,11 +162,14 @@ func (rs *routingConfigStore) DeleteRoutingConfigTx(tx store.Tx, serviceID strin
 func (rs *routingConfigStore) GetRoutingConfigsForCache(mtime time.Time,
 	firstUpdate bool) ([]*model.RoutingConfig, error) {
 	str := "select id, in_bounds, out_bounds, revision,flag, ctime, mtime " +
-		"from routing_config where mtime > $1"
+		"from routing_config where mtime >= $1"
 	if firstUpdate {
 		str += " and flag != 1"
 	}
-	rows, err := rs.slave.Query(str, mtime)
+	since := watermarks.since(mtime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := rs.slave.cacheReader().QueryContext(ctx, "routingConfigStore.GetRoutingConfigsForCache", str, since)
 	if err != nil {
 		log.Errorf("[Store][database] query routing configs with mtime err: %s", err.Error())
 		return nil, err
@@ -175,7 +179,10 @@ func (rs *routingConfigStore) GetRoutingConfigsForCache(mtime time.Time,
 		return nil, err
 	}
 
-	return out, nil
+	return dedupSlice(watermarks, "routing_config", since, firstUpdate, out,
+		func(conf *model.RoutingConfig) (string, string) {
+			return conf.ID, rowVersion(conf.ModifyTime, conf.Revision)
+		}), nil
 }
 
 // GetRoutingConfigWithService 根据服务名+namespace获取对应的配置
@@ -184,7 +191,7 @@ func (rs *routingConfigStore) GetRoutingConfigWithService(name string, namespace
 	str := "select routing_config.id, in_bounds, out_bounds, revision, flag, ctime, mtime " +
 		"from (select id from service where name = $1 and namespace = $2) as service, " +
 		"routing_config where service.id = routing_config.id and routing_config.flag = 0"
-	rows, err := rs.master.Query(str, name, namespace)
+	rows, err := rs.master.Query("routingConfigStore.GetRoutingConfigWithService", str, name, namespace)
 	if err != nil {
 		log.Errorf("[Store][database] query routing config with service(%s, %s) err: %s",
 			name, namespace, err.Error())
@@ -207,7 +214,7 @@ func (rs *routingConfigStore) GetRoutingConfigWithService(name string, namespace
 func (rs *routingConfigStore) GetRoutingConfigWithID(id string) (*model.RoutingConfig, error) {
 	str := "select routing_config.id, in_bounds, out_bounds, revision, flag, ctime, mtime " +
 		"from routing_config where id = $1 and flag = 0"
-	rows, err := rs.master.Query(str, id)
+	rows, err := rs.master.Query("routingConfigStore.GetRoutingConfigWithID", str, id)
 	if err != nil {
 		log.Errorf("[Store][database] query routing with id(%s) err: %s", id, err.Error())
 		return nil, err
@@ -234,7 +241,7 @@ func (rs *routingConfigStore) GetRoutingConfigs(filter map[string]string,
 	index = index1
 	countStr := genQueryRoutingConfigCountSQL() + filterStr
 	var total uint32
-	err := rs.master.QueryRow(countStr, args...).Scan(&total)
+	err := rs.master.QueryRow("routingConfigStore.GetRoutingConfigs", countStr, args...).Scan(&total)
 	switch {
 	case err == sql.ErrNoRows:
 		return 0, nil, nil
@@ -247,7 +254,7 @@ func (rs *routingConfigStore) GetRoutingConfigs(filter map[string]string,
 	str := genQueryRoutingConfigSQL() + filterStr +
 		fmt.Sprintf(" order by routing_config.mtime desc limit $%d offset $%d", index, index+1)
 	args = append(args, limit, offset)
-	rows, err := rs.master.Query(str, args...)
+	rows, err := rs.master.Query("routingConfigStore.GetRoutingConfigs", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get routing configs query err: %s", err.Error())
 		return 0, nil, err
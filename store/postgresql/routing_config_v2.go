@@ -18,6 +18,7 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -33,7 +34,7 @@ var _ store.RoutingConfigStoreV2 = (*routingConfigStoreV2)(nil)
 // RoutingConfigStoreV2 impl
 type routingConfigStoreV2 struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateRoutingConfigV2 Add a new routing configuration
@@ -48,7 +49,7 @@ func (r *routingConfigStoreV2) CreateRoutingConfigV2(conf *model.RouterConfig) e
 	}
 
 	err := RetryTransaction("CreateRoutingConfigV2", func() error {
-		tx, err := r.master.Begin()
+		tx, err := r.master.Begin("routingConfigStoreV2.CreateRoutingConfigV2")
 		if err != nil {
 			return err
 		}
@@ -122,7 +123,7 @@ func (r *routingConfigStoreV2) createRoutingConfigV2Tx(tx *BaseTx, conf *model.R
 // UpdateRoutingConfigV2 Update a routing configuration
 func (r *routingConfigStoreV2) UpdateRoutingConfigV2(conf *model.RouterConfig) error {
 
-	tx, err := r.master.Begin()
+	tx, err := r.master.Begin("routingConfigStoreV2.UpdateRoutingConfigV2")
 	if err != nil {
 		return err
 	}
@@ -237,12 +238,15 @@ func (r *routingConfigStoreV2) DeleteRoutingConfigV2(ruleID string) error {
 func (r *routingConfigStoreV2) GetRoutingConfigsV2ForCache(
 	mtime time.Time, firstUpdate bool) ([]*model.RouterConfig, error) {
 	str := "select id, name, policy, config, enable, revision, flag, priority, " +
-		"description, ctime, mtime, etime from routing_config_v2 where mtime > $1 "
+		"description, ctime, mtime, etime from routing_config_v2 where mtime >= $1 "
 
 	if firstUpdate {
 		str += " and flag != 1"
 	}
-	rows, err := r.slave.Query(str, mtime)
+	since := watermarks.since(mtime)
+	ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+	defer cancel()
+	rows, err := r.slave.cacheReader().QueryContext(ctx, "routingConfigStoreV2.GetRoutingConfigsV2ForCache", str, since)
 	if err != nil {
 		log.Errorf("[Store][database] query routing configs v2 with mtime err: %s", err.Error())
 		return nil, err
@@ -252,13 +256,16 @@ func (r *routingConfigStoreV2) GetRoutingConfigsV2ForCache(
 		return nil, err
 	}
 
-	return out, nil
+	return dedupSlice(watermarks, "routing_config_v2", since, firstUpdate, out,
+		func(conf *model.RouterConfig) (string, string) {
+			return conf.ID, rowVersion(conf.ModifyTime, conf.Revision)
+		}), nil
 }
 
 // GetRoutingConfigV2WithID Pull the routing configuration according to the rules ID
 func (r *routingConfigStoreV2) GetRoutingConfigV2WithID(ruleID string) (*model.RouterConfig, error) {
 
-	tx, err := r.master.Begin()
+	tx, err := r.master.Begin("routingConfigStoreV2.GetRoutingConfigV2WithID")
 	if err != nil {
 		return nil, err
 	}
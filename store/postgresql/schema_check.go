@@ -0,0 +1,247 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// SchemaCheckOff 不校验表结构
+	SchemaCheckOff = "off"
+	// SchemaCheckWarn 校验表结构，存在差异时只打印日志
+	SchemaCheckWarn = "warn"
+	// SchemaCheckStrict 校验表结构，存在严重差异时拒绝启动
+	SchemaCheckStrict = "strict"
+)
+
+var (
+	createTableRegex = regexp.MustCompile(`(?s)CREATE TABLE IF NOT EXISTS "?(\w+)"? \((.*?)\n\)\n;`)
+	columnRegex      = regexp.MustCompile(`(?m)^\s+"(\w+)" `)
+	addColumnRegex   = regexp.MustCompile(
+		`ALTER TABLE (?:IF EXISTS )?"?(\w+)"? ADD COLUMN (?:IF NOT EXISTS )?"?(\w+)"?`)
+	createIndexRegex = regexp.MustCompile(
+		`CREATE (?:UNIQUE )?INDEX (?:IF NOT EXISTS )?"?(\w+)"? ON "?(\w+)"?(?: USING \w+)? \(\s*"?(\w+)"?`)
+)
+
+// expectedSchema 期望的表结构，由内置的迁移文件解析得到
+type expectedSchema struct {
+	// tables 表名 -> 列名
+	tables map[string]map[string]struct{}
+	// indexes 表名 -> 索引名 -> 索引的第一列
+	indexes map[string]map[string]string
+}
+
+// loadExpectedSchema 解析内置迁移文件中的建表、加列以及建索引语句
+func loadExpectedSchema() (*expectedSchema, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	expect := &expectedSchema{
+		tables:  map[string]map[string]struct{}{},
+		indexes: map[string]map[string]string{},
+	}
+	for _, m := range migrations {
+		for _, match := range createTableRegex.FindAllStringSubmatch(m.sql, -1) {
+			columns := expect.table(match[1])
+			for _, column := range columnRegex.FindAllStringSubmatch(match[2], -1) {
+				columns[column[1]] = struct{}{}
+			}
+		}
+		for _, match := range addColumnRegex.FindAllStringSubmatch(m.sql, -1) {
+			expect.table(match[1])[match[2]] = struct{}{}
+		}
+		for _, match := range createIndexRegex.FindAllStringSubmatch(m.sql, -1) {
+			if _, ok := expect.indexes[match[2]]; !ok {
+				expect.indexes[match[2]] = map[string]string{}
+			}
+			expect.indexes[match[2]][match[1]] = match[3]
+		}
+	}
+	return expect, nil
+}
+
+func (e *expectedSchema) table(name string) map[string]struct{} {
+	if _, ok := e.tables[name]; !ok {
+		e.tables[name] = map[string]struct{}{}
+	}
+	return e.tables[name]
+}
+
+// schemaDrift 实际表结构与期望表结构的差异
+type schemaDrift struct {
+	missingTables  []string
+	missingColumns []string
+	// missingCriticalIndexes cache 增量查询依赖的 mtime 索引
+	missingCriticalIndexes []string
+	missingIndexes         []string
+}
+
+// empty 是否不存在差异
+func (d *schemaDrift) empty() bool {
+	return len(d.missingTables)+len(d.missingColumns)+len(d.missingCriticalIndexes)+len(d.missingIndexes) == 0
+}
+
+// critical 是否存在导致查询失败或者 cache 增量查询全表扫描的差异
+func (d *schemaDrift) critical() bool {
+	return len(d.missingTables)+len(d.missingColumns)+len(d.missingCriticalIndexes) > 0
+}
+
+// String 差异报告
+func (d *schemaDrift) String() string {
+	var lines []string
+	for _, item := range []struct {
+		title string
+		items []string
+	}{
+		{"missing tables", d.missingTables},
+		{"missing columns", d.missingColumns},
+		{"missing mtime indexes required by cache incremental queries", d.missingCriticalIndexes},
+		{"missing indexes", d.missingIndexes},
+	} {
+		if len(item.items) > 0 {
+			lines = append(lines, fmt.Sprintf("%s(%d): %s", item.title, len(item.items), strings.Join(item.items, ", ")))
+		}
+	}
+	return strings.Join(lines, "; ")
+}
+
+// diff 对比期望的表结构与数据库中实际的表结构
+func (e *expectedSchema) diff(actualColumns map[string]map[string]struct{},
+	actualIndexes map[string]map[string]struct{}) *schemaDrift {
+	drift := &schemaDrift{}
+	for table, columns := range e.tables {
+		actual, ok := actualColumns[table]
+		if !ok {
+			drift.missingTables = append(drift.missingTables, table)
+			continue
+		}
+		for column := range columns {
+			if _, ok := actual[column]; !ok {
+				drift.missingColumns = append(drift.missingColumns, table+"."+column)
+			}
+		}
+	}
+	for table, indexes := range e.indexes {
+		if _, ok := actualColumns[table]; !ok {
+			continue
+		}
+		for index, firstColumn := range indexes {
+			if _, ok := actualIndexes[table][index]; ok {
+				continue
+			}
+			if firstColumn == "mtime" {
+				drift.missingCriticalIndexes = append(drift.missingCriticalIndexes, table+"."+index)
+			} else {
+				drift.missingIndexes = append(drift.missingIndexes, table+"."+index)
+			}
+		}
+	}
+	for _, items := range [][]string{drift.missingTables, drift.missingColumns,
+		drift.missingCriticalIndexes, drift.missingIndexes} {
+		sort.Strings(items)
+	}
+	return drift
+}
+
+// checkSchema 对比数据库中当前 schema 的表结构与内置迁移文件定义的表结构
+func (b *BaseDB) checkSchema(ctx context.Context) (*schemaDrift, error) {
+	expect, err := loadExpectedSchema()
+	if err != nil {
+		return nil, err
+	}
+	columns, err := b.loadCatalog(ctx, "SELECT table_name, column_name FROM information_schema.columns "+
+		"WHERE table_schema = current_schema()")
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := b.loadCatalog(ctx, "SELECT tablename, indexname FROM pg_indexes "+
+		"WHERE schemaname = current_schema()")
+	if err != nil {
+		return nil, err
+	}
+	return expect.diff(columns, indexes), nil
+}
+
+// loadCatalog 查询系统表，返回 表名 -> 列名/索引名
+func (b *BaseDB) loadCatalog(ctx context.Context, query string) (map[string]map[string]struct{}, error) {
+	rows, err := b.QueryContext(ctx, "BaseDB.loadCatalog", query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := map[string]map[string]struct{}{}
+	for rows.Next() {
+		var table, name string
+		if err := rows.Scan(&table, &name); err != nil {
+			return nil, err
+		}
+		if _, ok := ret[table]; !ok {
+			ret[table] = map[string]struct{}{}
+		}
+		ret[table][name] = struct{}{}
+	}
+	return ret, rows.Err()
+}
+
+// verifySchema 启动时校验表结构，strict 模式下存在严重差异或者无法完成校验时返回错误
+func verifySchema(ctx context.Context, db *BaseDB, mode string) error {
+	if mode == SchemaCheckOff {
+		return nil
+	}
+	drift, err := db.checkSchema(ctx)
+	if err != nil {
+		log.Errorf("[Store][database] check schema err: %s", err.Error())
+		if mode == SchemaCheckStrict {
+			return err
+		}
+		return nil
+	}
+	if drift.empty() {
+		log.Infof("[Store][database] check schema pass")
+		return nil
+	}
+	if !drift.critical() {
+		log.Warnf("[Store][database] schema drift: %s", drift.String())
+		return nil
+	}
+	log.Errorf("[Store][database] critical schema drift: %s", drift.String())
+	if mode == SchemaCheckStrict {
+		return fmt.Errorf("critical schema drift: %s", drift.String())
+	}
+	return nil
+}
+
+// parseSchemaCheckMode 解析表结构校验模式，默认只打印日志
+func parseSchemaCheckMode(opt interface{}) (string, error) {
+	if opt == nil {
+		return SchemaCheckWarn, nil
+	}
+	mode, _ := opt.(string)
+	switch mode {
+	case SchemaCheckOff, SchemaCheckWarn, SchemaCheckStrict:
+		return mode, nil
+	}
+	return "", fmt.Errorf("config Plugin %s:schemaCheck must be %s, %s or %s",
+		STORENAME, SchemaCheckOff, SchemaCheckWarn, SchemaCheckStrict)
+}
@@ -0,0 +1,101 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoadExpectedSchema(t *testing.T) {
+	Convey("从迁移文件解析期望的表结构", t, func() {
+		expect, err := loadExpectedSchema()
+		So(err, ShouldBeNil)
+		So(expect.tables, ShouldContainKey, "instance")
+		So(expect.tables["instance"], ShouldContainKey, "mtime")
+		So(expect.tables["config_file_template"], ShouldContainKey, "id")
+		So(expect.tables["config_file_release"], ShouldContainKey, "release_type")
+		So(expect.indexes["instance"]["idx_mtime"], ShouldEqual, "mtime")
+		for table, columns := range expect.tables {
+			So(len(columns), ShouldBeGreaterThan, 0)
+			So(table, ShouldNotContainSubstring, "CONSTRAINT")
+		}
+	})
+}
+
+func TestSchemaDrift(t *testing.T) {
+	expect := &expectedSchema{
+		tables: map[string]map[string]struct{}{
+			"instance": {"id": {}, "mtime": {}},
+			"service":  {"id": {}},
+		},
+		indexes: map[string]map[string]string{
+			"instance": {"idx_mtime": "mtime", "idx_host": "host"},
+			"service":  {"idx_name": "name"},
+		},
+	}
+	Convey("表结构一致", t, func() {
+		drift := expect.diff(map[string]map[string]struct{}{
+			"instance": {"id": {}, "mtime": {}, "extra": {}},
+			"service":  {"id": {}},
+		}, map[string]map[string]struct{}{
+			"instance": {"idx_mtime": {}, "idx_host": {}},
+			"service":  {"idx_name": {}},
+		})
+		So(drift.empty(), ShouldBeTrue)
+		So(drift.critical(), ShouldBeFalse)
+	})
+	Convey("缺少普通索引不是严重差异", t, func() {
+		drift := expect.diff(map[string]map[string]struct{}{
+			"instance": {"id": {}, "mtime": {}},
+			"service":  {"id": {}},
+		}, map[string]map[string]struct{}{
+			"instance": {"idx_mtime": {}},
+		})
+		So(drift.critical(), ShouldBeFalse)
+		So(drift.missingIndexes, ShouldResemble, []string{"instance.idx_host", "service.idx_name"})
+	})
+	Convey("缺少表、列以及 mtime 索引是严重差异", t, func() {
+		drift := expect.diff(map[string]map[string]struct{}{
+			"instance": {"id": {}},
+		}, map[string]map[string]struct{}{
+			"instance": {"idx_host": {}},
+		})
+		So(drift.critical(), ShouldBeTrue)
+		So(drift.missingTables, ShouldResemble, []string{"service"})
+		So(drift.missingColumns, ShouldResemble, []string{"instance.mtime"})
+		So(drift.missingCriticalIndexes, ShouldResemble, []string{"instance.idx_mtime"})
+		So(drift.missingIndexes, ShouldBeEmpty)
+		So(drift.String(), ShouldContainSubstring, "instance.idx_mtime")
+	})
+}
+
+func TestParseSchemaCheckMode(t *testing.T) {
+	Convey("解析表结构校验模式", t, func() {
+		mode, err := parseSchemaCheckMode(nil)
+		So(err, ShouldBeNil)
+		So(mode, ShouldEqual, SchemaCheckWarn)
+		mode, err = parseSchemaCheckMode("strict")
+		So(err, ShouldBeNil)
+		So(mode, ShouldEqual, SchemaCheckStrict)
+		_, err = parseSchemaCheckMode("fail")
+		So(err, ShouldNotBeNil)
+		_, err = parseSchemaCheckMode(true)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -31,7 +31,7 @@ import (
 // serviceStore 实现了ServiceStore
 type serviceStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // AddService 增加服务
@@ -49,7 +49,7 @@ func (ss *serviceStore) AddService(s *model.Service) error {
 
 // addService add service
 func (ss *serviceStore) addService(s *model.Service) error {
-	tx, err := ss.master.Begin()
+	tx, err := ss.master.Begin("serviceStore.addService")
 	if err != nil {
 		return err
 	}
@@ -98,7 +98,7 @@ func (ss *serviceStore) DeleteService(id, serviceName, namespaceName string) err
 
 // deleteService 删除服务的内部函数
 func (ss *serviceStore) deleteService(id, serviceName, namespaceName string) error {
-	tx, err := ss.master.Begin()
+	tx, err := ss.master.Begin("serviceStore.deleteService")
 	if err != nil {
 		return err
 	}
@@ -180,7 +180,7 @@ func (ss *serviceStore) UpdateServiceAlias(alias *model.Service, needUpdateOwner
 
 // updateServiceAlias update service alias
 func (ss *serviceStore) updateServiceAlias(alias *model.Service, needUpdateOwner bool) error {
-	tx, err := ss.master.Begin()
+	tx, err := ss.master.Begin("serviceStore.updateServiceAlias")
 	if err != nil {
 		log.Errorf("[Store][database] update service alias tx begin err: %s", err.Error())
 		return err
@@ -264,7 +264,7 @@ func (ss *serviceStore) UpdateService(service *model.Service, needUpdateOwner bo
 
 // updateService update service
 func (ss *serviceStore) updateService(service *model.Service, needUpdateOwner bool) error {
-	tx, err := ss.master.Begin()
+	tx, err := ss.master.Begin("serviceStore.updateService")
 	if err != nil {
 		log.Errorf("[Store][database] update service tx begin err: %s", err.Error())
 		return err
@@ -344,7 +344,8 @@ func (ss *serviceStore) GetSourceServiceToken(name string, namespace string) (*m
 		"where name = $1 and namespace = $2 and flag = 0 " +
 		"and (reference is null or reference = '')"
 	var out model.Service
-	err := ss.master.QueryRow(str, name, namespace).Scan(&out.ID, &out.Token, &out.PlatformID)
+	err := ss.master.QueryRow("serviceStore.GetSourceServiceToken", str, name, namespace).
+		Scan(&out.ID, &out.Token, &out.PlatformID)
 	switch {
 	case err == sql.ErrNoRows:
 		return nil, nil
@@ -392,34 +393,47 @@ func (ss *serviceStore) GetServices(serviceFilters, serviceMetas map[string]stri
 // GetServicesCount 获取所有服务总数
 func (ss *serviceStore) GetServicesCount() (uint32, error) {
 	countStr := "select count(*) from service where flag = 0"
-	return queryEntryCount(ss.master, countStr, nil)
+	return queryEntryCount(ss.master, "serviceStore.GetServicesCount", countStr, nil)
 }
 
 // GetMoreServices 根据modify_time获取增量数据
 func (ss *serviceStore) GetMoreServices(mtime time.Time, firstUpdate, disableBusiness, needMeta bool) (
 	map[string]*model.Service, error) {
+	// 首次拉取的服务与元数据分两条语句查询，需要读取同一个快照
+	tx, err := ss.slave.beginCacheLoad("serviceStore.GetMoreServices")
+	if err != nil {
+		log.Errorf("[Store][database] get more services begin tx err: %s", err.Error())
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	handler := tx.Query
+	since := watermarks.since(mtime)
+	key := fmt.Sprintf("service:%t:%t", disableBusiness, needMeta)
+	version := func(service *model.Service) string {
+		return rowVersion(service.ModifyTime, service.Revision)
+	}
 	if needMeta {
-		services, err := getMoreServiceWithMeta(ss.slave.Query, mtime, firstUpdate, disableBusiness)
+		services, err := getMoreServiceWithMeta(handler, since, firstUpdate, disableBusiness)
 		if err != nil {
 			log.Errorf("[Store][database] get more service+meta err: %s", err.Error())
 			return nil, err
 		}
-		return services, nil
+		return dedupMap(watermarks, key, since, firstUpdate, services, version), nil
 	}
 
-	services, err := getMoreServiceMain(ss.slave.Query, mtime, firstUpdate, disableBusiness)
+	services, err := getMoreServiceMain(handler, since, firstUpdate, disableBusiness)
 	if err != nil {
 		log.Errorf("[Store][database] get more service main err: %s", err.Error())
 		return nil, err
 	}
-	return services, nil
+	return dedupMap(watermarks, key, since, firstUpdate, services, version), nil
 }
 
 // GetSystemServices 获取系统服务
 func (ss *serviceStore) GetSystemServices() ([]*model.Service, error) {
 	str := genServiceSelectSQL()
 	str += " from service where flag = 0 and namespace = $1"
-	rows, err := ss.master.Query(str, SystemNamespace)
+	rows, err := ss.master.Query("serviceStore.GetSystemServices", str, SystemNamespace)
 	if err != nil {
 		log.Errorf("[Store][database] get system service query err: %s", err.Error())
 		return nil, err
@@ -470,7 +484,7 @@ func (ss *serviceStore) getServiceAliasesInfo(filter map[string]string, offset u
 	indexSort := 1
 
 	queryStmt, args := genServiceAliasWhereSQLAndArgs(baseStr, filter, order, offset, limit, indexSort)
-	rows, err := ss.master.Query(queryStmt, args...)
+	rows, err := ss.master.Query("serviceStore.getServiceAliasesInfo", queryStmt, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get service aliases query(%s) err: %s", queryStmt, err.Error())
 		return nil, err
@@ -498,7 +512,7 @@ func (ss *serviceStore) getServiceAliasesCount(filter map[string]string) (uint32
 	baseStr := "select count(*) from service as alias " +
 		"inner join service as source on alias.reference = source.id and alias.flag != 1 "
 	str, args := genServiceAliasWhereSQLAndArgs(baseStr, filter, nil, 0, 1, 1)
-	return queryEntryCount(ss.master, str, args)
+	return queryEntryCount(ss.master, "serviceStore.getServiceAliasesCount", str, args)
 }
 
 // getServices 根据相关条件查询对应服务，不包括别名
@@ -543,7 +557,7 @@ func (ss *serviceStore) getServices(sFilters, sMetas map[string]string, iFilters
 	str += opStr
 	args = append(args, opArgs...)
 
-	rows, err := ss.master.Query(str, args...)
+	rows, err := ss.master.Query("serviceStore.getServices", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get services by filter query(%s) err: %s", str, err.Error())
 		return nil, err
@@ -584,7 +598,7 @@ func (ss *serviceStore) getServicesCount(
 		args = append(args, filterArgs...)
 	}
 
-	return queryEntryCount(ss.master, str, args)
+	return queryEntryCount(ss.master, "serviceStore.getServicesCount", str, args)
 }
 
 // fetchRowServices 根据rows，获取到services，并且批量获取对应的metadata
@@ -603,7 +617,7 @@ func (ss *serviceStore) fetchRowServices(rows *sql.Rows) ([]*model.Service, erro
 	}
 
 	err = BatchQuery("get-service-metadata", data, func(objects []interface{}) error {
-		rows, batchErr := batchQueryServiceMeta(ss.master.Query, objects)
+		rows, batchErr := batchQueryServiceMeta(ss.master.queryHandler("serviceStore.fetchRowServices"), objects)
 		if batchErr != nil {
 			return batchErr
 		}
@@ -644,7 +658,7 @@ func (ss *serviceStore) getServiceMeta(id string) (map[string]string, error) {
 
 	// 从metadata表中获取数据
 	metaStr := "select mkey, mvalue from service_metadata where id = $1"
-	rows, err := ss.master.Query(metaStr, id)
+	rows, err := ss.master.Query("serviceStore.getServiceMeta", metaStr, id)
 	if err != nil {
 		log.Errorf("[Store][database] get service metadata query err: %s", err.Error())
 		return nil, err
@@ -678,7 +692,7 @@ func (ss *serviceStore) getService(name string, namespace string) (*model.Servic
 // getServiceMain 获取服务表的信息，不包括metadata
 func (ss *serviceStore) getServiceMain(name string, namespace string) (*model.Service, error) {
 	str := genServiceSelectSQL() + " from service where name = $1 and namespace = $2"
-	rows, err := ss.master.Query(str, name, namespace)
+	rows, err := ss.master.Query("serviceStore.getServiceMain", str, name, namespace)
 	if err != nil {
 		log.Errorf("[Store][database] get service err: %s", err.Error())
 		return nil, err
@@ -699,7 +713,7 @@ func (ss *serviceStore) getServiceMain(name string, namespace string) (*model.Se
 // getServiceByID 根据服务ID获取服务详情的内部函数
 func (ss *serviceStore) getServiceByID(serviceID string) (*model.Service, error) {
 	str := genServiceSelectSQL() + " from service where service.id = $1"
-	rows, err := ss.master.Query(str, serviceID)
+	rows, err := ss.master.Query("serviceStore.getServiceByID", str, serviceID)
 	if err != nil {
 		log.Errorf("[Store][database] get service by id query err: %s", err.Error())
 		return nil, err
@@ -1183,7 +1197,7 @@ func (ss *serviceStore) GetServicesBatch(services []*model.Service) ([]*model.Se
 	}
 	str += ")"
 
-	rows, err := ss.master.Query(str, args...)
+	rows, err := ss.master.Query("serviceStore.GetServicesBatch", str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] query services batch err: %s", err.Error())
 		return nil, err
@@ -1230,7 +1244,7 @@ func addOwnerServiceMap(tx *BaseTx, service, namespace, owner string) error {
 		}
 		if len(args) != 0 {
 			addSql = strings.TrimSuffix(addSql, ",")
-			stmt, err := tx.Prepare(addSql)
+			stmt, err := tx.prepareDynamic(addSql)
 			if err != nil {
 				return err
 			}
@@ -42,7 +42,7 @@ var (
 
 type serviceContractStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // CreateServiceContract 创建服务契约
@@ -50,15 +50,16 @@ func (s *serviceContractStore) CreateServiceContract(contract *model.ServiceCont
 	addSql := "INSERT INTO service_contract(id, name, namespace, service, protocol, version, revision, flag, content, ctime, mtime) " +
 		"VALUES ($1, $2, $3, $4, $5, $6, $7, 0, $8, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)"
 
-	_, err := s.master.Exec(addSql, contract.ID, contract.Name, contract.Namespace, contract.Service,
-		contract.Protocol, contract.Version, contract.Revision, contract.Content)
+	_, err := s.master.Exec("serviceContractStore.CreateServiceContract", addSql, contract.ID, contract.Name,
+		contract.Namespace, contract.Service, contract.Protocol, contract.Version, contract.Revision, contract.Content)
 	return store.Error(err)
 }
 
 // UpdateServiceContract 更新服务契约信息
 func (s *serviceContractStore) UpdateServiceContract(contract *model.ServiceContract) error {
 	updateSql := "UPDATE service_contract SET content = $1, revision = $2, mtime = CURRENT_TIMESTAMP WHERE id = $3"
-	_, err := s.master.Exec(updateSql, contract.Content, contract.Revision, contract.ID)
+	_, err := s.master.Exec("serviceContractStore.UpdateServiceContract", updateSql,
+		contract.Content, contract.Revision, contract.ID)
 	if err != nil {
 		return err
 	}
@@ -90,7 +91,7 @@ func (s *serviceContractStore) GetServiceContract(id string) (data *model.Enrich
 		"ctime, mtime FROM service_contract WHERE flag = 0 AND id = $1"
 
 	args := []interface{}{id}
-	rows, err := s.master.Query(querySql, args...)
+	rows, err := s.master.Query("serviceContractStore.GetServiceContract", querySql, args...)
 	if err != nil {
 		log.Error("[Store][Contract] list contract ", zap.String("query", querySql), zap.Any("args", args))
 		return nil, store.Error(err)
@@ -124,8 +125,8 @@ func (s *serviceContractStore) GetServiceContract(id string) (data *model.Enrich
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 		}
-		contract.CreateTime = time.Unix(int64(ctimeFloat), 0)
-		contract.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+		contract.CreateTime = epochToTime(ctimeFloat)
+		contract.ModifyTime = epochToTime(mtimeFloat)
 
 		list = append(list, &contract)
 	}
@@ -218,8 +219,10 @@ func (s *serviceContractStore) GetMoreServiceContracts(firstUpdate bool, mtime t
 		mtime = time.Unix(0, 1)
 		querySql += " AND flag = 0 "
 	}
+	since := watermarks.since(mtime)
 
-	tx, err := s.slave.Begin()
+	// 契约以及契约详情在同一个快照中读取
+	tx, err := s.slave.beginCacheLoad("serviceContractStore.GetMoreServiceContracts")
 	if err != nil {
 		log.Error("[Store][Contract] list contract for cache when begin tx", zap.Error(err))
 		return nil, store.Error(err)
@@ -228,7 +231,7 @@ func (s *serviceContractStore) GetMoreServiceContracts(firstUpdate bool, mtime t
 		_ = tx.Commit()
 	}()
 
-	rows, err := tx.Query(querySql, mtime)
+	rows, err := tx.Query(querySql, since)
 	if err != nil {
 		log.Error("[Store][Contract] list contract for cache when query", zap.Error(err))
 		return nil, store.Error(err)
@@ -262,8 +265,8 @@ func (s *serviceContractStore) GetMoreServiceContracts(firstUpdate bool, mtime t
 			return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 		}
 
-		contract.CreateTime = time.Unix(int64(ctimeFloat), 0)
-		contract.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+		contract.CreateTime = epochToTime(ctimeFloat)
+		contract.ModifyTime = epochToTime(mtimeFloat)
 
 		list = append(list, &model.EnrichServiceContract{
 			ServiceContract: contract,
@@ -276,7 +279,7 @@ func (s *serviceContractStore) GetMoreServiceContracts(firstUpdate bool, mtime t
 			"sd.ctime, sd.mtime, COALESCE(sd.source, 1) " +
 			"FROM service_contract_detail sd LEFT JOIN service_contract sc ON sd.contract_id = sc.id " +
 			"WHERE sc.mtime >= $1"
-		detailRows, err := tx.Query(queryDetailSql, mtime)
+		detailRows, err := tx.Query(queryDetailSql, since)
 		if err != nil {
 			log.Error("[Store][Contract] list contract detail", zap.String("query sql", queryDetailSql), zap.Error(err))
 			return nil, store.Error(err)
@@ -309,8 +312,8 @@ func (s *serviceContractStore) GetMoreServiceContracts(firstUpdate bool, mtime t
 			if err != nil {
 				return nil, fmt.Errorf("failed to parse modify_time: %v", err)
 			}
-			detailItem.CreateTime = time.Unix(int64(ctimeFloat), 0)
-			detailItem.ModifyTime = time.Unix(int64(mtimeFloat), 0)
+			detailItem.CreateTime = epochToTime(ctimeFloat)
+			detailItem.ModifyTime = epochToTime(mtimeFloat)
 			switch int(source) {
 			case 2:
 				detailItem.Source = service_manage.InterfaceDescriptor_Client
@@ -330,5 +333,8 @@ func (s *serviceContractStore) GetMoreServiceContracts(firstUpdate bool, mtime t
 			item.Format()
 		}
 	}
-	return list, nil
+	return dedupSlice(watermarks, "service_contract", since, firstUpdate, list,
+		func(contract *model.EnrichServiceContract) (string, string) {
+			return contract.ID, rowVersion(contract.ModifyTime, contract.Revision)
+		}), nil
 }
@@ -0,0 +1,256 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultSlaveWeight 只读实例的默认权重
+	DefaultSlaveWeight = 1
+	// DefaultSlaveCheckInterval 只读实例健康检查的默认间隔，单位秒
+	DefaultSlaveCheckInterval = 5
+	// unknownLag 尚未探测到复制延迟
+	unknownLag = -1
+)
+
+// replicationLagSql 查询只读实例的复制延迟，没有待回放的 WAL 时认为延迟为0
+const replicationLagSql = "SELECT CASE WHEN NOT pg_is_in_recovery() THEN 0 " +
+	"WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 " +
+	"ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END"
+
+// slaveNode 单个只读实例
+type slaveNode struct {
+	*BaseDB
+	weight  int
+	healthy int32
+	lag     int64
+}
+
+// name 只读实例的标识
+func (n *slaveNode) name() string {
+	return n.cfg.dbAddr + ":" + n.cfg.dbPort
+}
+
+// isHealthy 只读实例是否可用
+func (n *slaveNode) isHealthy() bool {
+	return atomic.LoadInt32(&n.healthy) == 1
+}
+
+// setHealthy 设置只读实例的健康状态，返回状态是否发生变化
+func (n *slaveNode) setHealthy(healthy bool) bool {
+	var val int32
+	if healthy {
+		val = 1
+	}
+	return atomic.SwapInt32(&n.healthy, val) != val
+}
+
+// getLag 获取最近一次探测到的复制延迟，未知时返回 unknownLag
+func (n *slaveNode) getLag() time.Duration {
+	return time.Duration(atomic.LoadInt64(&n.lag))
+}
+
+// refreshLag 通过 pg_last_xact_replay_timestamp 探测复制延迟
+func (n *slaveNode) refreshLag(ctx context.Context) error {
+	var seconds float64
+	if err := n.DB.QueryRowContext(ctx, replicationLagSql).Scan(&seconds); err != nil {
+		atomic.StoreInt64(&n.lag, unknownLag)
+		return err
+	}
+	atomic.StoreInt64(&n.lag, int64(seconds*float64(time.Second)))
+	reportSlaveLag(n.name(), seconds)
+	return nil
+}
+
+// slavePool 只读数据库池
+// 读请求按权重分发到健康的只读实例，所有只读实例均不可用时回退到主库
+type slavePool struct {
+	master        *BaseDB
+	nodes         []*slaveNode
+	checkInterval time.Duration
+	// maxLag cache 增量读取允许的最大复制延迟，为0时不校验
+	maxLag time.Duration
+	cancel context.CancelFunc
+}
+
+// newSlavePool 新建只读数据库池，nodes 为空时所有读请求均由主库承担
+func newSlavePool(master *BaseDB, nodes []*slaveNode, checkInterval, maxLag time.Duration) *slavePool {
+	for _, node := range nodes {
+		if node.weight <= 0 {
+			node.weight = DefaultSlaveWeight
+		}
+		node.setHealthy(true)
+		atomic.StoreInt64(&node.lag, unknownLag)
+	}
+	if checkInterval <= 0 {
+		checkInterval = DefaultSlaveCheckInterval * time.Second
+	}
+	return &slavePool{master: master, nodes: nodes, checkInterval: checkInterval, maxLag: maxLag}
+}
+
+// start 启动只读实例的健康检查
+func (p *slavePool) start() {
+	if len(p.nodes) == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.checkNodes(ctx)
+	go p.checkLoop(ctx)
+}
+
+// checkLoop 定时 ping 所有只读实例，失败的实例被摘除，恢复后重新加入
+func (p *slavePool) checkLoop(ctx context.Context) {
+	ticker := time.NewTicker(p.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.checkNodes(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkNodes 检查所有只读实例的健康状态
+func (p *slavePool) checkNodes(ctx context.Context) {
+	for _, node := range p.nodes {
+		checkCtx, cancel := context.WithTimeout(ctx, p.checkInterval)
+		err := node.PingContext(checkCtx)
+		if err == nil {
+			if lagErr := node.refreshLag(checkCtx); lagErr != nil {
+				log.Warnf("[Store][database] slave(%s) query replication lag err: %s", node.name(), lagErr.Error())
+			}
+		}
+		cancel()
+		if !node.setHealthy(err == nil) {
+			continue
+		}
+		if err != nil {
+			log.Errorf("[Store][database] slave(%s) ping err: %s, eject it", node.name(), err.Error())
+		} else {
+			log.Infof("[Store][database] slave(%s) recovered", node.name())
+		}
+	}
+}
+
+// lagAcceptable 只读实例的复制延迟是否在阈值内，延迟未知时认为超出阈值
+func (p *slavePool) lagAcceptable(node *slaveNode) bool {
+	if p.maxLag <= 0 {
+		return true
+	}
+	lag := node.getLag()
+	return lag != unknownLag && lag <= p.maxLag
+}
+
+// pick 按权重随机选择一个健康的只读实例，没有健康的实例则返回主库
+func (p *slavePool) pick() *BaseDB {
+	return p.pickNode(func(node *slaveNode) bool {
+		return true
+	})
+}
+
+// cacheReader 选择用于 cache 增量读取的实例
+// 复制延迟超过阈值的只读实例会丢失 mtime 窗口内的变更，不参与选择，均不满足时返回主库
+func (p *slavePool) cacheReader() *BaseDB {
+	return p.pickNode(p.lagAcceptable)
+}
+
+// beginCacheLoad 为一次 cache 加载选择一个实例并开启可重复读事务
+// 加载过程中的所有查询都需要在该事务内执行，读取同一个实例的同一个快照
+func (p *slavePool) beginCacheLoad(label string) (*BaseTx, error) {
+	tx, err := p.cacheReader().beginWithClass(context.Background(), opCacheLoad, label)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.createReadView(); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return tx, nil
+}
+
+// pickNode 在健康且满足 filter 的只读实例中按权重随机选择，没有满足条件的实例则返回主库
+func (p *slavePool) pickNode(filter func(node *slaveNode) bool) *BaseDB {
+	total := 0
+	candidates := make([]*slaveNode, 0, len(p.nodes))
+	for _, node := range p.nodes {
+		if node.isHealthy() && filter(node) {
+			candidates = append(candidates, node)
+			total += node.weight
+		}
+	}
+	if total == 0 {
+		return p.master
+	}
+
+	r := rand.Intn(total)
+	for _, node := range candidates {
+		if r < node.weight {
+			return node.BaseDB
+		}
+		r -= node.weight
+	}
+	return p.master
+}
+
+// Query 在选中的实例上执行查询
+func (p *slavePool) Query(label string, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.pick().Query(label, query, args...)
+}
+
+// queryHandler 在选中的实例上执行查询的 QueryHandler
+func (p *slavePool) queryHandler(label string) QueryHandler {
+	return func(query string, args ...interface{}) (*sql.Rows, error) {
+		return p.Query(label, query, args...)
+	}
+}
+
+// QueryRow 在选中的实例上执行单行查询
+func (p *slavePool) QueryRow(label string, query string, args ...interface{}) *sql.Row {
+	return p.pick().QueryRow(label, query, args...)
+}
+
+// Begin 在选中的实例上开启事务
+func (p *slavePool) Begin(label string) (*BaseTx, error) {
+	return p.pick().Begin(label)
+}
+
+// processWithTransaction 在选中的实例上执行事务
+func (p *slavePool) processWithTransaction(label string, handle func(tx *BaseTx) error) error {
+	return p.pick().processWithTransaction(label, handle)
+}
+
+// Close 停止健康检查并关闭所有只读实例，主库由调用方关闭
+func (p *slavePool) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	for _, node := range p.nodes {
+		_ = node.Close()
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSlavePoolPick(t *testing.T) {
+	master := &BaseDB{cfg: &dbConfig{dbAddr: "master"}}
+	Convey("没有只读实例时使用主库", t, func() {
+		pool := newSlavePool(master, nil, 0, 0)
+		So(pool.pick(), ShouldEqual, master)
+	})
+	Convey("按权重分发到健康的只读实例", t, func() {
+		slave1 := &slaveNode{BaseDB: &BaseDB{cfg: &dbConfig{dbAddr: "slave1"}}, weight: 1}
+		slave2 := &slaveNode{BaseDB: &BaseDB{cfg: &dbConfig{dbAddr: "slave2"}}, weight: 3}
+		pool := newSlavePool(master, []*slaveNode{slave1, slave2}, 0, 0)
+
+		hits := map[*BaseDB]int{}
+		for i := 0; i < 4000; i++ {
+			hits[pool.pick()]++
+		}
+		So(hits[master], ShouldEqual, 0)
+		So(hits[slave1.BaseDB], ShouldBeGreaterThan, 700)
+		So(hits[slave2.BaseDB], ShouldBeGreaterThan, 2700)
+
+		Convey("摘除不健康的只读实例", func() {
+			slave2.setHealthy(false)
+			for i := 0; i < 100; i++ {
+				So(pool.pick(), ShouldEqual, slave1.BaseDB)
+			}
+		})
+		Convey("只读实例均不可用时回退主库", func() {
+			slave1.setHealthy(false)
+			slave2.setHealthy(false)
+			So(pool.pick(), ShouldEqual, master)
+		})
+	})
+}
+
+func TestSlavePoolCacheReader(t *testing.T) {
+	master := &BaseDB{cfg: &dbConfig{dbAddr: "master"}}
+	slave1 := &slaveNode{BaseDB: &BaseDB{cfg: &dbConfig{dbAddr: "slave1"}}}
+	slave2 := &slaveNode{BaseDB: &BaseDB{cfg: &dbConfig{dbAddr: "slave2"}}}
+
+	Convey("未配置延迟阈值时不校验复制延迟", t, func() {
+		pool := newSlavePool(master, []*slaveNode{slave1}, 0, 0)
+		So(pool.cacheReader(), ShouldEqual, slave1.BaseDB)
+	})
+	Convey("复制延迟超过阈值的只读实例不参与cache增量读取", t, func() {
+		pool := newSlavePool(master, []*slaveNode{slave1, slave2}, 0, time.Second)
+		// 尚未探测到复制延迟
+		So(pool.cacheReader(), ShouldEqual, master)
+
+		atomic.StoreInt64(&slave1.lag, int64(5*time.Second))
+		atomic.StoreInt64(&slave2.lag, int64(100*time.Millisecond))
+		for i := 0; i < 100; i++ {
+			So(pool.cacheReader(), ShouldEqual, slave2.BaseDB)
+		}
+
+		atomic.StoreInt64(&slave2.lag, int64(2*time.Second))
+		So(pool.cacheReader(), ShouldEqual, master)
+		// 普通读请求不受复制延迟影响
+		So(pool.pick(), ShouldNotEqual, master)
+	})
+}
+
+func TestSlavePoolBeginCacheLoad(t *testing.T) {
+	Convey("一次 cache 加载在选中的实例上开启可重复读事务", t, func() {
+		master, drv := openFakeStmtDB("postgresql-begin-cache-load", 4)
+		defer master.DB.Close()
+		pool := newSlavePool(master, nil, 0, 0)
+
+		tx, err := pool.beginCacheLoad("TestSlavePoolBeginCacheLoad")
+		So(err, ShouldBeNil)
+		So(tx.Rollback(), ShouldBeNil)
+		statements := drv.statements()
+		So(statements, ShouldNotBeEmpty)
+		So(statements[len(statements)-1], ShouldEqual, "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ")
+	})
+}
+
+func TestParseSlaveConf(t *testing.T) {
+	Convey("兼容单个只读实例配置", t, func() {
+		_, slaves, err := parseDatabaseConf(map[string]interface{}{
+			"master": newTestStoreOption(),
+			"slave":  newTestStoreOption(),
+		})
+		So(err, ShouldBeNil)
+		So(len(slaves), ShouldEqual, 1)
+		So(slaves[0].weight, ShouldEqual, DefaultSlaveWeight)
+	})
+	Convey("解析多个带权重的只读实例", t, func() {
+		slave1 := newTestStoreOption()
+		slave1["weight"] = 2
+		slave2 := newTestStoreOption()
+		slave2["weight"] = 3
+		_, slaves, err := parseDatabaseConf(map[string]interface{}{
+			"master": newTestStoreOption(),
+			"slave":  []interface{}{slave1, slave2},
+		})
+		So(err, ShouldBeNil)
+		So(len(slaves), ShouldEqual, 2)
+		So(slaves[0].weight, ShouldEqual, 2)
+		So(slaves[1].weight, ShouldEqual, 3)
+	})
+}
@@ -0,0 +1,233 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSlowQueryTopN 默认保留的最慢语句指纹数量
+	DefaultSlowQueryTopN = 20
+)
+
+var (
+	// inListRegex 合并 IN (?, ?, ?) 及 VALUES (?, ?) 中个数不定的占位符
+	inListRegex = regexp.MustCompile(`\(\s*\?(\s*,\s*\?)*\s*\)`)
+	// tupleListRegex 合并批量写入时重复的 (?+) 元组
+	tupleListRegex = regexp.MustCompile(`\(\?\+\)(\s*,\s*\(\?\+\))+`)
+)
+
+// slowQueryConfig 慢查询配置
+type slowQueryConfig struct {
+	// threshold 耗时超过该值的语句记为慢查询，为0时关闭慢查询日志
+	threshold time.Duration
+	// topN 内存中保留的最慢语句指纹数量
+	topN int
+}
+
+// slowQueries 当前生效的慢查询日志，未开启时为 nil，在 Initialize 时设置
+var slowQueries *slowQueryLog
+
+// parseSlowQueryConfig 解析慢查询配置
+func parseSlowQueryConfig(opts interface{}) (slowQueryConfig, error) {
+	cfg := slowQueryConfig{topN: DefaultSlowQueryTopN}
+	if opts == nil {
+		return cfg, nil
+	}
+	obj, ok := opts.(map[interface{}]interface{})
+	if !ok {
+		return cfg, fmt.Errorf("config Plugin %s:slowQuery type must be map", STORENAME)
+	}
+	if val, ok := obj["threshold"]; ok {
+		d, err := time.ParseDuration(fmt.Sprintf("%v", val))
+		if err != nil || d < 0 {
+			return cfg, fmt.Errorf("config Plugin %s:slowQuery.threshold is invalid duration: %v", STORENAME, val)
+		}
+		cfg.threshold = d
+	}
+	if topN, _ := obj["topN"].(int); topN > 0 {
+		cfg.topN = topN
+	}
+	return cfg, nil
+}
+
+// SlowQueryStat 单个语句指纹的慢查询统计
+type SlowQueryStat struct {
+	// Fingerprint 归一化后的语句
+	Fingerprint string
+	// Caller 最近一次发起该语句的 store 方法
+	Caller string
+	// Count 慢查询次数
+	Count int64
+	// MaxDuration 最大耗时
+	MaxDuration time.Duration
+	// TotalDuration 累计耗时
+	TotalDuration time.Duration
+	// MaxRows 单次返回或影响的最大行数
+	MaxRows int64
+	// LastSeen 最近一次出现的时间
+	LastSeen time.Time
+}
+
+// slowQueryLog 记录慢查询日志，并按最大耗时保留最慢的 topN 个语句指纹
+type slowQueryLog struct {
+	threshold time.Duration
+	topN      int
+
+	lock  sync.Mutex
+	stats map[string]*SlowQueryStat
+}
+
+// newSlowQueryLog 新建慢查询日志，未配置阈值时返回 nil
+func newSlowQueryLog(cfg slowQueryConfig) *slowQueryLog {
+	if cfg.threshold <= 0 {
+		return nil
+	}
+	return &slowQueryLog{
+		threshold: cfg.threshold,
+		topN:      cfg.topN,
+		stats:     make(map[string]*SlowQueryStat, cfg.topN),
+	}
+}
+
+// observe 记录一次语句执行，耗时未超过阈值时直接忽略，caller 为语句 ctx 中记录的 store 方法
+func (l *slowQueryLog) observe(caller string, query string, duration time.Duration, rows int64) {
+	if l == nil || duration < l.threshold {
+		return
+	}
+	fingerprint := fingerprintSQL(query)
+	if caller == "" {
+		caller = unknownCaller
+	}
+	log.Warnf("[Store][database] slow query, caller: %s, duration: %s, rows: %d, sql: %s",
+		caller, duration, rows, fingerprint)
+	l.record(fingerprint, caller, duration, rows)
+}
+
+// record 更新语句指纹的统计，超出 topN 时淘汰最大耗时最小的指纹
+func (l *slowQueryLog) record(fingerprint, caller string, duration time.Duration, rows int64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	stat, ok := l.stats[fingerprint]
+	if !ok {
+		if len(l.stats) >= l.topN {
+			var fastest *SlowQueryStat
+			for _, item := range l.stats {
+				if fastest == nil || item.MaxDuration < fastest.MaxDuration {
+					fastest = item
+				}
+			}
+			if fastest.MaxDuration >= duration {
+				return
+			}
+			delete(l.stats, fastest.Fingerprint)
+		}
+		stat = &SlowQueryStat{Fingerprint: fingerprint}
+		l.stats[fingerprint] = stat
+	}
+	stat.Caller = caller
+	stat.Count++
+	stat.TotalDuration += duration
+	stat.LastSeen = time.Now()
+	if duration > stat.MaxDuration {
+		stat.MaxDuration = duration
+	}
+	if rows > stat.MaxRows {
+		stat.MaxRows = rows
+	}
+}
+
+// dump 按最大耗时从大到小返回当前保留的慢查询统计
+func (l *slowQueryLog) dump() []SlowQueryStat {
+	if l == nil {
+		return nil
+	}
+	l.lock.Lock()
+	ret := make([]SlowQueryStat, 0, len(l.stats))
+	for _, stat := range l.stats {
+		ret = append(ret, *stat)
+	}
+	l.lock.Unlock()
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].MaxDuration > ret[j].MaxDuration
+	})
+	return ret
+}
+
+// fingerprintSQL 归一化语句：字面量及占位符替换为 ?，合并不定长的占位符列表，压缩空白并转为小写
+func fingerprintSQL(query string) string {
+	var (
+		buf     strings.Builder
+		space   bool
+		inIdent bool
+	)
+	buf.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = buf.Len() > 0
+			inIdent = false
+			continue
+		case c == '\'':
+			// 字符串字面量，'' 为转义的单引号
+			for i++; i < len(query); i++ {
+				if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			c = '?'
+		case c == '$' && !inIdent && i+1 < len(query) && isDigit(query[i+1]):
+			for i+1 < len(query) && isDigit(query[i+1]) {
+				i++
+			}
+			c = '?'
+		case isDigit(c) && !inIdent:
+			for i+1 < len(query) && (isDigit(query[i+1]) || query[i+1] == '.') {
+				i++
+			}
+			c = '?'
+		}
+		if space {
+			buf.WriteByte(' ')
+			space = false
+		}
+		inIdent = c == '_' || c == '"' || isDigit(c) || ('a' <= c|32 && c|32 <= 'z')
+		if 'A' <= c && c <= 'Z' {
+			c |= 32
+		}
+		buf.WriteByte(c)
+	}
+	ret := inListRegex.ReplaceAllString(buf.String(), "(?+)")
+	return tupleListRegex.ReplaceAllString(ret, "(?+), ...")
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,351 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"time"
+)
+
+// openDB 打开数据库，开启慢查询日志时在驱动外层包装一层，统计每条语句的执行耗时及行数
+func openDB(driverName, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil || slowQueries == nil {
+		return db, err
+	}
+	drv := db.Driver()
+	_ = db.Close()
+
+	var connector driver.Connector = dsnConnector{dsn: dsn, driver: drv}
+	if dc, ok := drv.(driver.DriverContext); ok {
+		if connector, err = dc.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+	return sql.OpenDB(&slowQueryConnector{Connector: connector, log: slowQueries}), nil
+}
+
+// dsnConnector 适配未实现 driver.DriverContext 的驱动
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+// Connect 实现 driver.Connector
+func (c dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver 实现 driver.Connector
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// slowQueryConnector 为新建的连接包装慢查询统计
+type slowQueryConnector struct {
+	driver.Connector
+	log *slowQueryLog
+}
+
+// Connect 实现 driver.Connector
+func (c *slowQueryConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &slowQueryConn{Conn: conn, log: c.log}, nil
+}
+
+// slowQueryConn 统计语句耗时的连接，驱动可选实现的接口均透传给原始连接
+type slowQueryConn struct {
+	driver.Conn
+	log *slowQueryLog
+}
+
+// BeginTx 实现 driver.ConnBeginTx
+func (c *slowQueryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck
+}
+
+// PrepareContext 实现 driver.ConnPrepareContext
+func (c *slowQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &slowQueryStmt{Stmt: stmt, query: query, log: c.log, caller: callLabel(ctx)}, nil
+}
+
+// Prepare 实现 driver.Conn
+func (c *slowQueryConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// ExecContext 实现 driver.ExecerContext
+func (c *slowQueryConn) ExecContext(ctx context.Context, query string,
+	args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	observeExec(c.log, callLabel(ctx), query, start, result, err)
+	return result, err
+}
+
+// QueryContext 实现 driver.QueryerContext
+func (c *slowQueryConn) QueryContext(ctx context.Context, query string,
+	args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	return wrapRows(c.log, callLabel(ctx), query, start, rows, err)
+}
+
+// Ping 实现 driver.Pinger
+func (c *slowQueryConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession 实现 driver.SessionResetter
+func (c *slowQueryConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid 实现 driver.Validator
+func (c *slowQueryConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue 实现 driver.NamedValueChecker
+func (c *slowQueryConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// slowQueryStmt 统计预编译语句的执行耗时
+type slowQueryStmt struct {
+	driver.Stmt
+	query string
+	log   *slowQueryLog
+	// caller 预编译时 ctx 中记录的 store 方法，sql.Stmt.Exec 等不带 ctx 的执行使用该方法
+	caller string
+}
+
+// callerOf 执行语句的 store 方法，ctx 中没有记录时使用预编译时的方法
+func (s *slowQueryStmt) callerOf(ctx context.Context) string {
+	if caller := callLabel(ctx); caller != "" {
+		return caller
+	}
+	return s.caller
+}
+
+// ExecContext 实现 driver.StmtExecContext
+func (s *slowQueryStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	var (
+		result driver.Result
+		err    error
+		start  = time.Now()
+	)
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			result, err = s.Stmt.Exec(values) //nolint:staticcheck
+		}
+	}
+	observeExec(s.log, s.callerOf(ctx), s.query, start, result, err)
+	return result, err
+}
+
+// QueryContext 实现 driver.StmtQueryContext
+func (s *slowQueryStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	var (
+		rows  driver.Rows
+		err   error
+		start = time.Now()
+	)
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			rows, err = s.Stmt.Query(values) //nolint:staticcheck
+		}
+	}
+	return wrapRows(s.log, s.callerOf(ctx), s.query, start, rows, err)
+}
+
+// CheckNamedValue 实现 driver.NamedValueChecker
+func (s *slowQueryStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// namedValuesToValues 驱动不支持 context 时转换为按位置传递的参数
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, driver.ErrSkip
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
+
+// observeExec 记录写语句的耗时及影响行数
+func observeExec(l *slowQueryLog, caller, query string, start time.Time, result driver.Result, err error) {
+	duration := time.Since(start)
+	if err != nil || duration < l.threshold {
+		return
+	}
+	affected, _ := result.RowsAffected()
+	l.observe(caller, query, duration, affected)
+}
+
+// wrapRows 包装查询结果，在关闭时记录查询及读取数据的累计耗时和行数
+func wrapRows(l *slowQueryLog, caller, query string, start time.Time, rows driver.Rows,
+	err error) (driver.Rows, error) {
+	if err != nil {
+		return rows, err
+	}
+	return &slowQueryRows{Rows: rows, caller: caller, query: query, log: l, elapsed: time.Since(start)}, nil
+}
+
+// slowQueryRows 统计查询结果的读取耗时及行数
+// 只累计驱动内部的耗时，调用方处理每一行数据的时间不计入
+type slowQueryRows struct {
+	driver.Rows
+	caller  string
+	query   string
+	log     *slowQueryLog
+	elapsed time.Duration
+	rows    int64
+	closed  bool
+}
+
+// Next 实现 driver.Rows
+func (r *slowQueryRows) Next(dest []driver.Value) error {
+	start := time.Now()
+	err := r.Rows.Next(dest)
+	r.elapsed += time.Since(start)
+	if err == nil {
+		r.rows++
+	}
+	return err
+}
+
+// Close 实现 driver.Rows
+func (r *slowQueryRows) Close() error {
+	start := time.Now()
+	err := r.Rows.Close()
+	if !r.closed {
+		r.closed = true
+		r.log.observe(r.caller, r.query, r.elapsed+time.Since(start), r.rows)
+	}
+	return err
+}
+
+// HasNextResultSet 实现 driver.RowsNextResultSet
+func (r *slowQueryRows) HasNextResultSet() bool {
+	if rs, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return rs.HasNextResultSet()
+	}
+	return false
+}
+
+// NextResultSet 实现 driver.RowsNextResultSet
+func (r *slowQueryRows) NextResultSet() error {
+	if rs, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return rs.NextResultSet()
+	}
+	return io.EOF
+}
+
+// ColumnTypeScanType 实现 driver.RowsColumnTypeScanType
+func (r *slowQueryRows) ColumnTypeScanType(index int) reflect.Type {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return ct.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+// ColumnTypeDatabaseTypeName 实现 driver.RowsColumnTypeDatabaseTypeName
+func (r *slowQueryRows) ColumnTypeDatabaseTypeName(index int) string {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return ct.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+// ColumnTypeLength 实现 driver.RowsColumnTypeLength
+func (r *slowQueryRows) ColumnTypeLength(index int) (int64, bool) {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeLength); ok {
+		return ct.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+// ColumnTypeNullable 实现 driver.RowsColumnTypeNullable
+func (r *slowQueryRows) ColumnTypeNullable(index int) (bool, bool) {
+	if ct, ok := r.Rows.(driver.RowsColumnTypeNullable); ok {
+		return ct.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+// ColumnTypePrecisionScale 实现 driver.RowsColumnTypePrecisionScale
+func (r *slowQueryRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if ct, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return ct.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
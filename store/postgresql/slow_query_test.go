@@ -0,0 +1,157 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFingerprintSQL(t *testing.T) {
+	Convey("字面量及占位符替换为 ?", t, func() {
+		So(fingerprintSQL("SELECT id FROM instance WHERE  flag = 0 AND\n\tname = 'it''s' AND mtime > $12"),
+			ShouldEqual, "select id from instance where flag = ? and name = ? and mtime > ?")
+	})
+	Convey("标识符中的数字保持不变", t, func() {
+		So(fingerprintSQL("select t1.id, md5 from t1 limit 10"), ShouldEqual, "select t1.id, md5 from t1 limit ?")
+	})
+	Convey("合并不定长的 IN 列表和批量写入的元组", t, func() {
+		So(fingerprintSQL("delete from instance where id in ($1, $2,$3)"),
+			ShouldEqual, fingerprintSQL("delete from instance where id in ($1)"))
+		So(fingerprintSQL("insert into t (a, b) values ($1, $2), ($3, $4), ($5, $6)"),
+			ShouldEqual, "insert into t (a, b) values (?+), ...")
+	})
+}
+
+func TestParseSlowQueryConfig(t *testing.T) {
+	Convey("未配置时关闭慢查询日志", t, func() {
+		cfg, err := parseSlowQueryConfig(nil)
+		So(err, ShouldBeNil)
+		So(newSlowQueryLog(cfg), ShouldBeNil)
+	})
+	Convey("解析阈值及 topN", t, func() {
+		cfg, err := parseSlowQueryConfig(map[interface{}]interface{}{"threshold": "200ms", "topN": 5})
+		So(err, ShouldBeNil)
+		So(cfg.threshold, ShouldEqual, 200*time.Millisecond)
+		So(cfg.topN, ShouldEqual, 5)
+	})
+	Convey("非法的阈值", t, func() {
+		_, err := parseSlowQueryConfig(map[interface{}]interface{}{"threshold": "fast"})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestSlowQueryLogTopN(t *testing.T) {
+	Convey("只保留最大耗时最大的 topN 个指纹", t, func() {
+		l := newSlowQueryLog(slowQueryConfig{threshold: time.Millisecond, topN: 2})
+		l.record("a", "s.A", 10*time.Millisecond, 1)
+		l.record("b", "s.B", 30*time.Millisecond, 2)
+		l.record("c", "s.C", 5*time.Millisecond, 3)
+		l.record("a", "s.A", 20*time.Millisecond, 4)
+		l.record("d", "s.D", 40*time.Millisecond, 5)
+
+		stats := l.dump()
+		So(len(stats), ShouldEqual, 2)
+		So(stats[0].Fingerprint, ShouldEqual, "d")
+		So(stats[1].Fingerprint, ShouldEqual, "b")
+	})
+	Convey("累计同一指纹的次数及耗时", t, func() {
+		l := newSlowQueryLog(slowQueryConfig{threshold: time.Millisecond, topN: 2})
+		l.record("a", "s.A", 10*time.Millisecond, 1)
+		l.record("a", "s.A", 20*time.Millisecond, 4)
+
+		stats := l.dump()
+		So(len(stats), ShouldEqual, 1)
+		So(stats[0].Count, ShouldEqual, 2)
+		So(stats[0].MaxDuration, ShouldEqual, 20*time.Millisecond)
+		So(stats[0].TotalDuration, ShouldEqual, 30*time.Millisecond)
+		So(stats[0].MaxRows, ShouldEqual, 4)
+	})
+}
+
+// fakeSlowDriver 每次查询返回固定行数的驱动，用于验证慢查询统计
+type fakeSlowDriver struct{}
+
+func (fakeSlowDriver) Open(string) (driver.Conn, error) { return fakeSlowConn{}, nil }
+
+type fakeSlowConn struct{}
+
+func (fakeSlowConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (fakeSlowConn) Close() error                        { return nil }
+func (fakeSlowConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (fakeSlowConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeSlowRows{left: 3}, nil
+}
+
+type fakeSlowRows struct {
+	left int
+}
+
+func (r *fakeSlowRows) Columns() []string { return []string{"id"} }
+func (r *fakeSlowRows) Close() error      { return nil }
+
+func (r *fakeSlowRows) Next(dest []driver.Value) error {
+	if r.left == 0 {
+		return io.EOF
+	}
+	r.left--
+	dest[0] = int64(r.left)
+	return nil
+}
+
+// queryForTest 在 ctx 中记录调用方后执行查询
+func queryForTest(db *sql.DB, label string) error {
+	rows, err := db.QueryContext(withCallLabel(context.Background(), label),
+		"SELECT id FROM t WHERE id IN ($1, $2)", 1, 2)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func TestSlowQueryDriver(t *testing.T) {
+	sql.Register("postgresql-slow-query-test", fakeSlowDriver{})
+	old := slowQueries
+	defer func() {
+		slowQueries = old
+	}()
+
+	Convey("记录查询的行数及发起调用的 store 方法", t, func() {
+		slowQueries = newSlowQueryLog(slowQueryConfig{threshold: time.Nanosecond, topN: 5})
+		db, err := openDB("postgresql-slow-query-test", "")
+		So(err, ShouldBeNil)
+		defer db.Close()
+
+		So(queryForTest(db, "fakeCallerStore.QueryForTest"), ShouldBeNil)
+		stats := slowQueries.dump()
+		So(len(stats), ShouldEqual, 1)
+		So(stats[0].Fingerprint, ShouldEqual, "select id from t where id in (?+)")
+		So(stats[0].Caller, ShouldEqual, "fakeCallerStore.QueryForTest")
+		So(stats[0].MaxRows, ShouldEqual, 3)
+		So(stats[0].Count, ShouldEqual, 1)
+	})
+}
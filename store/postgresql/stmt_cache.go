@@ -0,0 +1,125 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+const (
+	// DefaultStmtCacheSize 预编译语句缓存的默认容量
+	DefaultStmtCacheSize = 256
+)
+
+// stmtCache 以 SQL 文本为 key 缓存在 sql.DB 上预编译的语句，超出容量时按 LRU 淘汰
+// 事务内通过 sql.Tx.Stmt 复用缓存的语句，同一连接上已预编译过的语句不再重复预编译
+type stmtCache struct {
+	db       *sql.DB
+	capacity int
+
+	lock  sync.Mutex
+	items map[string]*list.Element
+	lru   *list.List
+}
+
+// stmtEntry 缓存的预编译语句
+type stmtEntry struct {
+	query string
+	stmt  *sql.Stmt
+}
+
+// newStmtCache 新建预编译语句缓存，capacity 小于0时不缓存
+func newStmtCache(db *sql.DB, capacity int) *stmtCache {
+	if capacity < 0 {
+		return nil
+	}
+	if capacity == 0 {
+		capacity = DefaultStmtCacheSize
+	}
+	return &stmtCache{
+		db:       db,
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		lru:      list.New(),
+	}
+}
+
+// get 获取 query 对应的预编译语句，不存在时在 sql.DB 上预编译并加入缓存
+func (c *stmtCache) get(ctx context.Context, query string) (*sql.Stmt, error) {
+	if stmt := c.lookup(query); stmt != nil {
+		return stmt, nil
+	}
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// 并发预编译了同一条语句，使用先加入缓存的
+	if elem, ok := c.items[query]; ok {
+		_ = stmt.Close()
+		c.lru.MoveToFront(elem)
+		return elem.Value.(*stmtEntry).stmt, nil
+	}
+	c.items[query] = c.lru.PushFront(&stmtEntry{query: query, stmt: stmt})
+	for c.lru.Len() > c.capacity {
+		// 正在被事务使用的语句，sql.Stmt 会在事务结束后才真正关闭
+		oldest := c.lru.Remove(c.lru.Back()).(*stmtEntry)
+		delete(c.items, oldest.query)
+		_ = oldest.stmt.Close()
+	}
+	return stmt, nil
+}
+
+// lookup 查找缓存的预编译语句
+func (c *stmtCache) lookup(query string) *sql.Stmt {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.items[query]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*stmtEntry).stmt
+}
+
+// saturated 连接池是否已无空闲连接且无法新建连接
+func (c *stmtCache) saturated() bool {
+	stats := c.db.Stats()
+	return stats.MaxOpenConnections > 0 && stats.Idle == 0 && stats.InUse >= stats.MaxOpenConnections
+}
+
+// close 关闭所有缓存的预编译语句
+func (c *stmtCache) close() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, elem := range c.items {
+		_ = elem.Value.(*stmtEntry).stmt.Close()
+	}
+	c.items = map[string]*list.Element{}
+	c.lru.Init()
+}
@@ -0,0 +1,184 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// fakeStmtDriver 记录预编译及关闭次数的驱动
+type fakeStmtDriver struct {
+	prepared int64
+	closed   int64
+	lock     sync.Mutex
+	queries  []string
+}
+
+// statements 按顺序返回预编译过的语句
+func (d *fakeStmtDriver) statements() []string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return append([]string(nil), d.queries...)
+}
+
+func (d *fakeStmtDriver) Open(string) (driver.Conn, error) { return &fakeStmtConn{driver: d}, nil }
+
+type fakeStmtConn struct {
+	driver *fakeStmtDriver
+}
+
+func (c *fakeStmtConn) Prepare(query string) (driver.Stmt, error) {
+	atomic.AddInt64(&c.driver.prepared, 1)
+	c.driver.lock.Lock()
+	c.driver.queries = append(c.driver.queries, query)
+	c.driver.lock.Unlock()
+	return &fakeStmt{driver: c.driver}, nil
+}
+func (c *fakeStmtConn) Close() error              { return nil }
+func (c *fakeStmtConn) Begin() (driver.Tx, error) { return fakeStmtTx{}, nil }
+
+type fakeStmtTx struct{}
+
+func (fakeStmtTx) Commit() error   { return nil }
+func (fakeStmtTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	driver *fakeStmtDriver
+}
+
+func (s *fakeStmt) Close() error {
+	atomic.AddInt64(&s.driver.closed, 1)
+	return nil
+}
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) { return nil, driver.ErrSkip }
+
+func openFakeStmtDB(name string, capacity int) (*BaseDB, *fakeStmtDriver) {
+	drv := &fakeStmtDriver{}
+	sql.Register(name, drv)
+	db, _ := sql.Open(name, "")
+	return &BaseDB{DB: db, stmts: newStmtCache(db, capacity)}, drv
+}
+
+func execInTx(db *BaseDB, query string) error {
+	tx, err := db.BeginContext(context.Background(), "execInTx")
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err = stmt.Exec(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func TestStmtCacheReuse(t *testing.T) {
+	Convey("多个事务复用同一条预编译语句", t, func() {
+		db, drv := openFakeStmtDB("postgresql-stmt-cache-reuse", 4)
+		defer db.Close()
+		db.SetMaxOpenConns(2)
+
+		for i := 0; i < 3; i++ {
+			So(execInTx(db, "update instance set flag = 1 where id = $1"), ShouldBeNil)
+		}
+		warmed := atomic.LoadInt64(&drv.prepared)
+		So(warmed, ShouldBeLessThanOrEqualTo, 2)
+		for i := 0; i < 10; i++ {
+			So(execInTx(db, "update instance set flag = 1 where id = $1"), ShouldBeNil)
+		}
+		So(atomic.LoadInt64(&drv.prepared), ShouldEqual, warmed)
+		// 只有第一个事务在事务连接上预编译的语句随事务结束关闭
+		So(atomic.LoadInt64(&drv.closed), ShouldEqual, 1)
+	})
+}
+
+func TestStmtCacheEvict(t *testing.T) {
+	Convey("超出容量时淘汰最久未使用的语句，Close 时关闭所有语句", t, func() {
+		db, drv := openFakeStmtDB("postgresql-stmt-cache-evict", 2)
+		defer db.DB.Close()
+
+		for _, query := range []string{"select 1", "select 2", "select 1", "select 3"} {
+			_, err := db.Prepare(query)
+			So(err, ShouldBeNil)
+		}
+		So(db.stmts.lookup("select 2"), ShouldBeNil)
+		So(db.stmts.lookup("select 1"), ShouldNotBeNil)
+		So(db.stmts.lookup("select 3"), ShouldNotBeNil)
+		So(atomic.LoadInt64(&drv.prepared), ShouldEqual, 3)
+		So(atomic.LoadInt64(&drv.closed), ShouldEqual, 1)
+
+		db.stmts.close()
+		So(atomic.LoadInt64(&drv.closed), ShouldEqual, 3)
+	})
+}
+
+func TestStmtCacheMiss(t *testing.T) {
+	Convey("缓存未命中时直接在事务连接上预编译，事务结束后再加入缓存", t, func() {
+		db, drv := openFakeStmtDB("postgresql-stmt-cache-miss", 4)
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+
+		query := "delete from instance where id = $1"
+		tx, err := db.BeginContext(context.Background(), "TestStmtCacheMiss")
+		So(err, ShouldBeNil)
+		_, err = tx.Prepare(query)
+		So(err, ShouldBeNil)
+		So(atomic.LoadInt64(&drv.prepared), ShouldEqual, 1)
+		So(db.Stats().OpenConnections, ShouldEqual, 1)
+		So(db.stmts.lookup(query), ShouldBeNil)
+		So(tx.Commit(), ShouldBeNil)
+
+		So(db.stmts.lookup(query), ShouldNotBeNil)
+		So(execInTx(db, query), ShouldBeNil)
+		So(atomic.LoadInt64(&drv.prepared), ShouldEqual, 2)
+	})
+}
+
+func TestStmtCacheDynamic(t *testing.T) {
+	Convey("动态生成的语句不进入缓存", t, func() {
+		db, drv := openFakeStmtDB("postgresql-stmt-cache-dynamic", 4)
+		defer db.Close()
+
+		for _, query := range []string{
+			"delete from instance where id in ($1)",
+			"delete from instance where id in ($1,$2)",
+		} {
+			tx, err := db.BeginContext(context.Background(), "TestStmtCacheDynamic")
+			So(err, ShouldBeNil)
+			_, err = tx.prepareDynamic(query)
+			So(err, ShouldBeNil)
+			So(tx.Commit(), ShouldBeNil)
+			So(db.stmts.lookup(query), ShouldBeNil)
+		}
+		So(atomic.LoadInt64(&drv.prepared), ShouldEqual, 2)
+	})
+}
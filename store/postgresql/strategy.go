@@ -47,7 +47,7 @@ var (
 
 type strategyStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 func (s *strategyStore) AddStrategy(strategy *model.StrategyDetail) error {
@@ -69,7 +69,7 @@ func (s *strategyStore) AddStrategy(strategy *model.StrategyDetail) error {
 }
 
 func (s *strategyStore) addStrategy(strategy *model.StrategyDetail) error {
-	tx, err := s.master.Begin()
+	tx, err := s.master.Begin("strategyStore.addStrategy")
 	if err != nil {
 		return err
 	}
@@ -128,7 +128,7 @@ func (s *strategyStore) UpdateStrategy(strategy *model.ModifyStrategyDetail) err
 }
 
 func (s *strategyStore) updateStrategy(strategy *model.ModifyStrategyDetail) error {
-	tx, err := s.master.Begin()
+	tx, err := s.master.Begin("strategyStore.updateStrategy")
 	if err != nil {
 		return err
 	}
@@ -187,7 +187,7 @@ func (s *strategyStore) DeleteStrategy(id string) error {
 }
 
 func (s *strategyStore) deleteStrategy(id string) error {
-	tx, err := s.master.Begin()
+	tx, err := s.master.Begin("strategyStore.deleteStrategy")
 	if err != nil {
 		return err
 	}
@@ -334,7 +334,7 @@ func (s *strategyStore) deleteStrategyResources(tx *BaseTx, id string,
 
 // LooseAddStrategyResources loose add strategy resources
 func (s *strategyStore) LooseAddStrategyResources(resources []model.StrategyResource) error {
-	tx, err := s.master.Begin()
+	tx, err := s.master.Begin("strategyStore.LooseAddStrategyResources")
 	if err != nil {
 		return err
 	}
@@ -381,7 +381,7 @@ func (s *strategyStore) LooseAddStrategyResources(resources []model.StrategyReso
 
 // RemoveStrategyResources 删除策略的资源
 func (s *strategyStore) RemoveStrategyResources(resources []model.StrategyResource) error {
-	tx, err := s.master.Begin()
+	tx, err := s.master.Begin("strategyStore.RemoveStrategyResources")
 	if err != nil {
 		return err
 	}
@@ -432,7 +432,7 @@ func (s *strategyStore) GetStrategyDetail(id string) (*model.StrategyDetail, err
 	querySql := "SELECT ag.id, ag.name, ag.action, ag.owner, ag.default, ag.comment, ag.revision, ag.flag, " +
 		" ag.ctime, ag.mtime FROM auth_strategy AS ag WHERE ag.flag = 0 AND ag.id = $1"
 
-	row := s.master.QueryRow(querySql, id)
+	row := s.master.QueryRow("strategyStore.GetStrategyDetail", querySql, id)
 
 	return s.getStrategyDetail(row)
 }
@@ -452,7 +452,8 @@ func (s *strategyStore) GetDefaultStrategyDetailByPrincipal(principalId string,
 		"(SELECT DISTINCT strategy_id FROM auth_principal " +
 		"WHERE principal_id = $1 AND principal_role = $2)"
 
-	row := s.master.QueryRow(querySql, principalId, int(principalType))
+	row := s.master.QueryRow("strategyStore.GetDefaultStrategyDetailByPrincipal", querySql,
+		principalId, int(principalType))
 	return s.getStrategyDetail(row)
 }
 
@@ -475,11 +476,11 @@ func (s *strategyStore) getStrategyDetail(row *sql.Row) (*model.StrategyDetail,
 	ret.Valid = flag == 0
 	ret.Default = isDefault == 1
 
-	resArr, err := s.getStrategyResources(s.slave.Query, ret.ID)
+	resArr, err := s.getStrategyResources(s.slave.queryHandler("strategyStore.getStrategyDetail"), ret.ID)
 	if err != nil {
 		return nil, store.Error(err)
 	}
-	principals, err := s.getStrategyPrincipals(s.slave.Query, ret.ID)
+	principals, err := s.getStrategyPrincipals(s.slave.queryHandler("strategyStore.getStrategyDetail"), ret.ID)
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -512,8 +513,8 @@ func (s *strategyStore) listStrategies(filters map[string]string, offset uint32,
 		"LEFT JOIN auth_strategy_resource ar ON ag.id = ar.strategy_id) " +
 		"LEFT JOIN auth_principal ap ON ag.id = ap.strategy_id"
 
-	return s.queryStrategies(s.master.Query, filters, RuleFilters, querySql, countSql,
-		offset, limit, showDetail)
+	return s.queryStrategies(s.master.queryHandler("strategyStore.listStrategies"), filters, RuleFilters,
+		querySql, countSql, offset, limit, showDetail)
 }
 
 // queryStrategies 通用的查询策略列表
@@ -570,7 +571,7 @@ func (s *strategyStore) queryStrategies(
 		}
 	}
 
-	count, err := queryEntryCount(s.master, countSql, args)
+	count, err := queryEntryCount(s.master, "strategyStore.queryStrategies", countSql, args)
 	if err != nil {
 		return 0, nil, store.Error(err)
 	}
@@ -578,7 +579,7 @@ func (s *strategyStore) queryStrategies(
 	querySql += fmt.Sprintf(" GROUP BY ag.id ORDER BY ag.mtime LIMIT $%d OFFSET $%d ", idx, idx+1)
 	args = append(args, limit, offset)
 
-	ret, err := s.collectStrategies(s.master.Query, querySql, args, showDetail)
+	ret, err := s.collectStrategies(s.master.queryHandler("strategyStore.queryStrategies"), querySql, args, showDetail)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -618,11 +619,11 @@ func (s *strategyStore) collectStrategies(handler QueryHandler, querySql string,
 		idMap[detail.ID] = struct{}{}
 
 		if showDetail {
-			resArr, err := s.getStrategyResources(s.slave.Query, detail.ID)
+			resArr, err := s.getStrategyResources(s.slave.queryHandler("strategyStore.collectStrategies"), detail.ID)
 			if err != nil {
 				return nil, store.Error(err)
 			}
-			principals, err := s.getStrategyPrincipals(s.slave.Query, detail.ID)
+			principals, err := s.getStrategyPrincipals(s.slave.queryHandler("strategyStore.collectStrategies"), detail.ID)
 			if err != nil {
 				return nil, store.Error(err)
 			}
@@ -639,7 +640,8 @@ func (s *strategyStore) collectStrategies(handler QueryHandler, querySql string,
 
 func (s *strategyStore) GetStrategyDetailsForCache(mtime time.Time,
 	firstUpdate bool) ([]*model.StrategyDetail, error) {
-	tx, err := s.slave.Begin()
+	// 策略以及关联的资源、成员在同一个快照中读取
+	tx, err := s.slave.beginCacheLoad("strategyStore.GetStrategyDetailsForCache")
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -649,9 +651,10 @@ func (s *strategyStore) GetStrategyDetailsForCache(mtime time.Time,
 	querySql := "SELECT ag.id, ag.name, ag.action, ag.owner, ag.comment, ag.default, ag.revision, ag.flag, " +
 		" ag.ctime, ag.mtime FROM auth_strategy ag "
 
+	since := watermarks.since(mtime)
 	if !firstUpdate {
 		querySql += " WHERE ag.mtime >= $1"
-		args = append(args, mtime)
+		args = append(args, since)
 	}
 
 	rows, err := tx.Query(querySql, args...)
@@ -668,23 +671,32 @@ func (s *strategyStore) GetStrategyDetailsForCache(mtime time.Time,
 		if err != nil {
 			return nil, store.Error(err)
 		}
+		ret = append(ret, detail)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, store.Error(err)
+	}
+	// 同一个连接上不能同时读取多个结果集，读取完策略之后再查询关联的数据
+	_ = rows.Close()
 
-		resArr, err := s.getStrategyResources(s.slave.Query, detail.ID)
+	for _, detail := range ret {
+		resArr, err := s.getStrategyResources(tx.Query, detail.ID)
 		if err != nil {
 			return nil, store.Error(err)
 		}
-		principals, err := s.getStrategyPrincipals(s.slave.Query, detail.ID)
+		principals, err := s.getStrategyPrincipals(tx.Query, detail.ID)
 		if err != nil {
 			return nil, store.Error(err)
 		}
 
 		detail.Resources = resArr
 		detail.Principals = principals
-
-		ret = append(ret, detail)
 	}
 
-	return ret, nil
+	return dedupSlice(watermarks, "auth_strategy", since, firstUpdate, ret,
+		func(detail *model.StrategyDetail) (string, string) {
+			return detail.ID, rowVersion(detail.ModifyTime, detail.Revision)
+		}), nil
 }
 
 // GetStrategyResources 获取对应 principal 能操作的所有资源
@@ -695,7 +707,7 @@ func (s *strategyStore) GetStrategyResources(principalId string,
 		" ap.strategy_id FROM auth_principal ap join auth_strategy ar ON ap.strategy_id = ar.id WHERE ar.flag = 0 " +
 		" AND ap.principal_id = $1 AND ap.principal_role = $2 )"
 
-	rows, err := s.master.Query(querySql, principalId, principalRole)
+	rows, err := s.master.Query("strategyStore.GetStrategyResources", querySql, principalId, principalRole)
 	if err != nil {
 		switch err {
 		case sql.ErrNoRows:
@@ -804,7 +816,7 @@ func (s *strategyStore) cleanInvalidStrategy(name, owner string) error {
 	log.Info("[Store][Strategy] clean invalid auth_strategy",
 		zap.String("name", name), zap.String("owner", owner))
 
-	tx, err := s.master.Begin()
+	tx, err := s.master.Begin("strategyStore.cleanInvalidStrategy")
 	if err != nil {
 		return err
 	}
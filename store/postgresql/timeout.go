@@ -0,0 +1,94 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// opClass 操作类型，不同类型的操作使用不同的默认超时时间
+type opClass string
+
+const (
+	// opCacheLoad cache 的全量以及增量加载
+	opCacheLoad opClass = "cacheLoad"
+	// opWrite 写操作，也是未指定操作类型时的默认值
+	opWrite opClass = "write"
+	// opAdminCleanup 后台清理任务，例如清理已经软删除的实例
+	opAdminCleanup opClass = "adminCleanup"
+)
+
+var (
+	defaultOpTimeouts = map[opClass]time.Duration{
+		opCacheLoad:    120 * time.Second,
+		opWrite:        30 * time.Second,
+		opAdminCleanup: 120 * time.Second,
+	}
+	// opTimeouts 当前生效的各类操作超时时间，在 Initialize 时设置，为0时不限制
+	opTimeouts = defaultOpTimeouts
+)
+
+// parseTimeoutConfig 解析各类操作的默认超时时间，未配置的类型使用默认值
+func parseTimeoutConfig(opts interface{}) (map[opClass]time.Duration, error) {
+	timeouts := make(map[opClass]time.Duration, len(defaultOpTimeouts))
+	for class, timeout := range defaultOpTimeouts {
+		timeouts[class] = timeout
+	}
+	if opts == nil {
+		return timeouts, nil
+	}
+	obj, ok := opts.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config Plugin %s:timeout type must be map", STORENAME)
+	}
+	for key, val := range obj {
+		class := opClass(fmt.Sprintf("%v", key))
+		if _, ok := defaultOpTimeouts[class]; !ok {
+			return nil, fmt.Errorf("config Plugin %s:timeout.%s is unknown operation", STORENAME, class)
+		}
+		d, err := time.ParseDuration(fmt.Sprintf("%v", val))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("config Plugin %s:timeout.%s is invalid duration: %v", STORENAME, class, val)
+		}
+		timeouts[class] = d
+	}
+	return timeouts, nil
+}
+
+// newOpContext 按照操作类型的默认超时时间创建 context
+func newOpContext(parent context.Context, class opClass) (context.Context, context.CancelFunc) {
+	if timeout := opTimeouts[class]; timeout > 0 {
+		return context.WithTimeout(parent, timeout)
+	}
+	return context.WithCancel(parent)
+}
+
+// statementTimeoutSql 将 ctx 的剩余时间转换为事务级别的 statement_timeout，ctx 没有截止时间时返回空
+func statementTimeoutSql(ctx context.Context) string {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return ""
+	}
+	ms := time.Until(deadline).Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+	return fmt.Sprintf("SET LOCAL statement_timeout = %d", ms)
+}
@@ -0,0 +1,104 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseTimeoutConfig(t *testing.T) {
+	Convey("未配置时使用默认值", t, func() {
+		timeouts, err := parseTimeoutConfig(nil)
+		So(err, ShouldBeNil)
+		So(timeouts, ShouldResemble, defaultOpTimeouts)
+	})
+	Convey("解析各类操作的超时时间", t, func() {
+		timeouts, err := parseTimeoutConfig(map[interface{}]interface{}{
+			"cacheLoad": "1m", "write": "5s", "adminCleanup": "0s",
+		})
+		So(err, ShouldBeNil)
+		So(timeouts[opCacheLoad], ShouldEqual, time.Minute)
+		So(timeouts[opWrite], ShouldEqual, 5*time.Second)
+		So(timeouts[opAdminCleanup], ShouldEqual, 0)
+		// 不影响默认值
+		So(defaultOpTimeouts[opWrite], ShouldEqual, 30*time.Second)
+	})
+	Convey("非法的超时配置", t, func() {
+		_, err := parseTimeoutConfig(map[interface{}]interface{}{"read": "1s"})
+		So(err, ShouldNotBeNil)
+		_, err = parseTimeoutConfig(map[interface{}]interface{}{"write": "abc"})
+		So(err, ShouldNotBeNil)
+		_, err = parseTimeoutConfig("1s")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestNewOpContext(t *testing.T) {
+	defer func() {
+		opTimeouts = defaultOpTimeouts
+	}()
+	opTimeouts = map[opClass]time.Duration{opWrite: time.Second, opCacheLoad: 0}
+
+	Convey("按照操作类型设置截止时间", t, func() {
+		ctx, cancel := newOpContext(context.Background(), opWrite)
+		defer cancel()
+		deadline, ok := ctx.Deadline()
+		So(ok, ShouldBeTrue)
+		So(time.Until(deadline), ShouldBeLessThanOrEqualTo, time.Second)
+		So(statementTimeoutSql(ctx), ShouldStartWith, "SET LOCAL statement_timeout = ")
+	})
+	Convey("超时时间为0时不设置截止时间", t, func() {
+		ctx, cancel := newOpContext(context.Background(), opCacheLoad)
+		defer cancel()
+		_, ok := ctx.Deadline()
+		So(ok, ShouldBeFalse)
+		So(statementTimeoutSql(ctx), ShouldBeEmpty)
+	})
+	Convey("截止时间已过时statement_timeout至少为1ms", t, func() {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		So(statementTimeoutSql(ctx), ShouldEqual, "SET LOCAL statement_timeout = 1")
+	})
+}
+
+func TestTxOutlivesStatementTimeout(t *testing.T) {
+	defer func() {
+		opTimeouts = defaultOpTimeouts
+	}()
+	opTimeouts = map[opClass]time.Duration{opWrite: 20 * time.Millisecond}
+
+	Convey("超时时间只限制单条语句，事务持续时间超过超时时间仍可提交", t, func() {
+		db, _ := openFakeStmtDB("postgresql-tx-statement-timeout", 4)
+		defer db.Close()
+
+		tx, err := db.Begin("TestTxOutlivesStatementTimeout")
+		So(err, ShouldBeNil)
+		_, ok := tx.context().Deadline()
+		So(ok, ShouldBeFalse)
+		So(tx.timeout, ShouldEqual, 20*time.Millisecond)
+
+		time.Sleep(50 * time.Millisecond)
+		_, err = tx.Exec("update namespace set flag = 0 where name = $1", "default")
+		So(err, ShouldBeNil)
+		So(tx.Commit(), ShouldBeNil)
+	})
+}
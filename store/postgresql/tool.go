@@ -34,7 +34,7 @@ const (
 // GetUnixSecond 获取当前时间，单位秒
 func (t *toolStore) GetUnixSecond(maxWait time.Duration) (int64, error) {
 	startTime := time.Now()
-	rows, err := t.db.Query(nowSql)
+	rows, err := t.db.Query("toolStore.GetUnixSecond", nowSql)
 	if err != nil {
 		log.Errorf("[Store][database] query now err: %s", err.Error())
 		return 0, err
@@ -26,11 +26,69 @@ import (
 	"github.com/polarismesh/polaris/common/model"
 )
 
+const (
+	// LockWait 行锁被其他事务持有时等待释放，默认行为
+	LockWait = "wait"
+	// LockNoWait 行锁被其他事务持有时立即返回错误，可以通过 IsLockNotAvailable 判断
+	LockNoWait = "nowait"
+	// LockSkipLocked 跳过被其他事务锁住的行，单行加锁时按数据不存在处理，只能通过 SetLockWaitPolicy 按事务开启
+	LockSkipLocked = "skipLocked"
+
+	// lockNotAvailableCode SQLSTATE lock_not_available
+	lockNotAvailableCode = "55P03"
+)
+
+// lockWaitPolicy 新建事务默认的行锁等待策略，由 rowLockWait 配置
+var lockWaitPolicy = LockWait
+
 // transaction 事务; 不支持多协程并发操作，当前先支持单个协程串行操作
 type transaction struct {
 	tx     *BaseTx
 	failed bool // 判断事务执行是否失败
 	commit bool // 判断事务已经提交，如果已经提交，则Commit会立即返回
+	// waitPolicy 行锁被占用时的等待策略
+	waitPolicy string
+}
+
+// parseLockWaitPolicy 解析 rowLockWait 配置
+// 调用方会把 LockService、LockNamespace 返回的 nil 当作数据不存在，skipLocked 不能作为全局的默认策略
+func parseLockWaitPolicy(opt interface{}) (string, error) {
+	if opt == nil {
+		return LockWait, nil
+	}
+	policy, _ := opt.(string)
+	switch policy {
+	case LockWait, LockNoWait:
+		return policy, nil
+	}
+	return "", fmt.Errorf("config Plugin %s:rowLockWait must be %s or %s, %s can only be enabled per transaction",
+		STORENAME, LockWait, LockNoWait, LockSkipLocked)
+}
+
+// IsLockNotAvailable 判断错误是否因为 nowait 策略下行锁被其他事务持有
+func IsLockNotAvailable(err error) bool {
+	return sqlState(err) == lockNotAvailableCode
+}
+
+// SetLockWaitPolicy 修改当前事务后续加锁的等待策略
+func (t *transaction) SetLockWaitPolicy(policy string) error {
+	switch policy {
+	case LockWait, LockNoWait, LockSkipLocked:
+		t.waitPolicy = policy
+		return nil
+	}
+	return fmt.Errorf("invalid lock wait policy: %s", policy)
+}
+
+// lockClause 生成行锁子句，strength 为 UPDATE 或 SHARE
+func (t *transaction) lockClause(strength string) string {
+	switch t.waitPolicy {
+	case LockNoWait:
+		return " FOR " + strength + " NOWAIT"
+	case LockSkipLocked:
+		return " FOR " + strength + " SKIP LOCKED"
+	}
+	return " FOR " + strength
 }
 
 // Commit 提交事务，释放tx
@@ -83,13 +141,13 @@ func (t *transaction) LockBootstrap(key string, server string) error {
 
 // LockNamespace 排它锁，锁住指定命名空间
 func (t *transaction) LockNamespace(name string) (*model.Namespace, error) {
-	str := genNamespaceSelectSQL() + " where name = $1 and flag != 1"
+	str := genNamespaceSelectSQL() + " where name = $1 and flag != 1" + t.lockClause("UPDATE")
 	return t.getValidNamespace(str, name)
 }
 
 // RLockNamespace 共享锁，锁住命名空间
 func (t *transaction) RLockNamespace(name string) (*model.Namespace, error) {
-	str := genNamespaceSelectSQL() + " where name = $1 and flag != 1"
+	str := genNamespaceSelectSQL() + " where name = $1 and flag != 1" + t.lockClause("SHARE")
 	return t.getValidNamespace(str, name)
 }
 
@@ -114,14 +172,14 @@ func (t *transaction) DeleteNamespace(name string) error {
 // LockService 排它锁，锁住指定服务
 func (t *transaction) LockService(name string, namespace string) (*model.Service, error) {
 	str := genServiceSelectSQL() +
-		" from service where name = $1 and namespace = $2 and flag !=1"
+		" from service where name = $1 and namespace = $2 and flag !=1" + t.lockClause("UPDATE")
 	return t.getValidService(str, name, namespace)
 }
 
 // RLockService 共享锁，锁住指定服务
 func (t *transaction) RLockService(name string, namespace string) (*model.Service, error) {
 	str := genServiceSelectSQL() +
-		" from service where name = $1 and namespace = $2 and flag !=1"
+		" from service where name = $1 and namespace = $2 and flag !=1" + t.lockClause("SHARE")
 	return t.getValidService(str, name, namespace)
 }
 
@@ -141,11 +199,12 @@ func (t *transaction) BatchRLockServices(ids map[string]bool) (map[string]bool,
 		idx++
 		args = append(args, id)
 	}
-	str += ") and flag != 1"
+	str += ") and flag != 1" + t.lockClause("SHARE")
 	log.Infof("[Store][database] RLock services: %+v", args)
 	rows, err := t.tx.Query(str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] batch RLock services err: %s", err.Error())
+		t.failed = true
 		return nil, err
 	}
 	defer rows.Close()
@@ -0,0 +1,296 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"github.com/polarismesh/polaris/common/model"
+	"github.com/polarismesh/polaris/common/utils"
+	apiservice "github.com/polarismesh/specification/source/go/api/v1/service_manage"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestParseLockWaitPolicy(t *testing.T) {
+	Convey("默认等待行锁", t, func() {
+		policy, err := parseLockWaitPolicy(nil)
+		So(err, ShouldBeNil)
+		So(policy, ShouldEqual, LockWait)
+	})
+	Convey("合法与非法的配置", t, func() {
+		for _, item := range []string{LockWait, LockNoWait} {
+			policy, err := parseLockWaitPolicy(item)
+			So(err, ShouldBeNil)
+			So(policy, ShouldEqual, item)
+		}
+		_, err := parseLockWaitPolicy("skip")
+		So(err, ShouldNotBeNil)
+	})
+	Convey("skipLocked 只能按事务开启", t, func() {
+		_, err := parseLockWaitPolicy(LockSkipLocked)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestLockClause(t *testing.T) {
+	Convey("按等待策略生成行锁子句", t, func() {
+		tx := &transaction{waitPolicy: LockWait}
+		So(tx.lockClause("UPDATE"), ShouldEqual, " FOR UPDATE")
+		So(tx.lockClause("SHARE"), ShouldEqual, " FOR SHARE")
+		So(tx.SetLockWaitPolicy(LockNoWait), ShouldBeNil)
+		So(tx.lockClause("UPDATE"), ShouldEqual, " FOR UPDATE NOWAIT")
+		So(tx.SetLockWaitPolicy(LockSkipLocked), ShouldBeNil)
+		So(tx.lockClause("SHARE"), ShouldEqual, " FOR SHARE SKIP LOCKED")
+		So(tx.SetLockWaitPolicy("skip"), ShouldNotBeNil)
+		So(tx.waitPolicy, ShouldEqual, LockSkipLocked)
+	})
+	Convey("兼容两种驱动的锁冲突错误", t, func() {
+		So(IsLockNotAvailable(&pq.Error{Code: "55P03"}), ShouldBeTrue)
+		So(IsLockNotAvailable(fmt.Errorf("wrap: %w", &pgconn.PgError{Code: "55P03"})), ShouldBeTrue)
+		So(IsLockNotAvailable(&pq.Error{Code: "40P01"}), ShouldBeFalse)
+		So(IsLockNotAvailable(nil), ShouldBeFalse)
+	})
+}
+
+// requireDB 获取测试用的存储，数据库不可用时跳过测试
+func requireDB(t *testing.T) *PostgresqlStore {
+	obj := initConf()
+	if obj.master == nil {
+		t.Skip("postgresql is not available")
+	}
+	return obj
+}
+
+// waitBlocked 断言 ch 在持有者提交前不会返回
+func waitBlocked(t *testing.T, ch <-chan error) {
+	select {
+	case <-ch:
+		t.Fatal("lock acquired while held by another transaction")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// waitAcquired 等待 ch 在持有者提交后返回
+func waitAcquired(t *testing.T, ch <-chan error) error {
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("lock not acquired after holder committed")
+	}
+	return nil
+}
+
+func TestRowLockConcurrency(t *testing.T) {
+	obj := requireDB(t)
+	name := "test-row-lock"
+	_ = obj.AddNamespace(&model.Namespace{Name: name, Owner: "polaris"})
+	newTx := func(policy string) *transaction {
+		tx, err := obj.CreateTransaction()
+		So(err, ShouldBeNil)
+		nt := tx.(*transaction)
+		So(nt.SetLockWaitPolicy(policy), ShouldBeNil)
+		return nt
+	}
+
+	Convey("排它锁与 nowait、skipLocked", t, func() {
+		holder := newTx(LockWait)
+		defer func() { _ = holder.Commit() }()
+		ns, err := holder.LockNamespace(name)
+		So(err, ShouldBeNil)
+		So(ns, ShouldNotBeNil)
+
+		noWait := newTx(LockNoWait)
+		_, err = noWait.RLockNamespace(name)
+		So(IsLockNotAvailable(err), ShouldBeTrue)
+		So(noWait.Commit(), ShouldBeNil)
+
+		skip := newTx(LockSkipLocked)
+		ns, err = skip.LockNamespace(name)
+		So(err, ShouldBeNil)
+		So(ns, ShouldBeNil)
+		So(skip.Commit(), ShouldBeNil)
+	})
+	Convey("共享锁之间不互斥，与排它锁互斥", t, func() {
+		first := newTx(LockNoWait)
+		second := newTx(LockNoWait)
+		_, err := first.RLockNamespace(name)
+		So(err, ShouldBeNil)
+		_, err = second.RLockNamespace(name)
+		So(err, ShouldBeNil)
+
+		writer := newTx(LockNoWait)
+		_, err = writer.LockNamespace(name)
+		So(IsLockNotAvailable(err), ShouldBeTrue)
+		_ = writer.Commit()
+		So(first.Commit(), ShouldBeNil)
+		So(second.Commit(), ShouldBeNil)
+	})
+	Convey("等待策略下阻塞到持有者提交", t, func() {
+		holder := newTx(LockWait)
+		_, err := holder.LockNamespace(name)
+		So(err, ShouldBeNil)
+
+		acquired := make(chan error, 1)
+		waiter := newTx(LockWait)
+		go func() {
+			_, err := waiter.LockNamespace(name)
+			acquired <- err
+		}()
+		waitBlocked(t, acquired)
+		So(holder.Commit(), ShouldBeNil)
+		So(waitAcquired(t, acquired), ShouldBeNil)
+		So(waiter.Commit(), ShouldBeNil)
+	})
+}
+
+func TestServiceLockConcurrency(t *testing.T) {
+	obj := requireDB(t)
+	namespace := "test-service-lock"
+	newTx := func(policy string) *transaction {
+		tx, err := obj.CreateTransaction()
+		So(err, ShouldBeNil)
+		nt := tx.(*transaction)
+		So(nt.SetLockWaitPolicy(policy), ShouldBeNil)
+		return nt
+	}
+	newService := func() *model.Service {
+		suffix := utils.NewUUID()
+		svc := &model.Service{
+			ID:        suffix,
+			Name:      "svc-" + suffix,
+			Namespace: namespace,
+			Token:     suffix,
+			Owner:     "polaris",
+			Revision:  suffix,
+		}
+		So(obj.AddService(svc), ShouldBeNil)
+		return svc
+	}
+	newInstance := func(svc *model.Service) *model.Instance {
+		id := utils.NewUUID()
+		return &model.Instance{
+			Proto: &apiservice.Instance{
+				Id:        wrapperspb.String(id),
+				Service:   wrapperspb.String(svc.Name),
+				Namespace: wrapperspb.String(svc.Namespace),
+				Host:      wrapperspb.String("127.0.0.1"),
+				Port:      wrapperspb.UInt32(8080),
+				Healthy:   wrapperspb.Bool(true),
+				Isolate:   wrapperspb.Bool(false),
+			},
+			ServiceID: svc.ID,
+			Valid:     true,
+		}
+	}
+	_ = obj.AddNamespace(&model.Namespace{Name: namespace, Owner: "polaris"})
+
+	Convey("注册持有服务共享锁时，删除等待注册提交", t, func() {
+		svc := newService()
+		register := newTx(LockWait)
+		locked, err := register.BatchRLockServices(map[string]bool{svc.ID: true})
+		So(err, ShouldBeNil)
+		So(locked[svc.ID], ShouldBeTrue)
+
+		deleted := make(chan error, 1)
+		remover := newTx(LockWait)
+		go func() {
+			if _, err := remover.LockService(svc.Name, svc.Namespace); err != nil {
+				deleted <- err
+				return
+			}
+			deleted <- remover.DeleteService(svc.Name, svc.Namespace)
+		}()
+		waitBlocked(t, deleted)
+
+		instance := newInstance(svc)
+		So(obj.BatchAddInstances([]*model.Instance{instance}), ShouldBeNil)
+		So(register.Commit(), ShouldBeNil)
+		So(waitAcquired(t, deleted), ShouldBeNil)
+		So(remover.Commit(), ShouldBeNil)
+
+		saved, err := obj.GetInstance(instance.ID())
+		So(err, ShouldBeNil)
+		So(saved, ShouldNotBeNil)
+	})
+	Convey("删除持有服务排它锁时，注册等待删除提交并看到服务已删除", t, func() {
+		svc := newService()
+		remover := newTx(LockWait)
+		locked, err := remover.LockService(svc.Name, svc.Namespace)
+		So(err, ShouldBeNil)
+		So(locked, ShouldNotBeNil)
+		So(remover.DeleteService(svc.Name, svc.Namespace), ShouldBeNil)
+
+		type lockResult struct {
+			service *model.Service
+			err     error
+		}
+		registered := make(chan lockResult, 1)
+		register := newTx(LockWait)
+		go func() {
+			service, err := register.RLockService(svc.Name, svc.Namespace)
+			registered <- lockResult{service: service, err: err}
+		}()
+		select {
+		case <-registered:
+			t.Fatal("register locked a service while it is being deleted")
+		case <-time.After(200 * time.Millisecond):
+		}
+		So(remover.Commit(), ShouldBeNil)
+		select {
+		case ret := <-registered:
+			So(ret.err, ShouldBeNil)
+			So(ret.service, ShouldBeNil)
+		case <-time.After(5 * time.Second):
+			t.Fatal("register not unblocked after delete committed")
+		}
+		So(register.Commit(), ShouldBeNil)
+	})
+	Convey("注册之间共享服务锁，nowait 删除立即失败", t, func() {
+		svc := newService()
+		first := newTx(LockNoWait)
+		service, err := first.RLockService(svc.Name, svc.Namespace)
+		So(err, ShouldBeNil)
+		So(service, ShouldNotBeNil)
+
+		second := newTx(LockNoWait)
+		locked, err := second.BatchRLockServices(map[string]bool{svc.ID: true})
+		So(err, ShouldBeNil)
+		So(locked[svc.ID], ShouldBeTrue)
+
+		remover := newTx(LockNoWait)
+		_, err = remover.LockService(svc.Name, svc.Namespace)
+		So(IsLockNotAvailable(err), ShouldBeTrue)
+		_ = remover.Commit()
+
+		So(first.Commit(), ShouldBeNil)
+		So(second.Commit(), ShouldBeNil)
+
+		remover = newTx(LockNoWait)
+		service, err = remover.LockService(svc.Name, svc.Namespace)
+		So(err, ShouldBeNil)
+		So(service, ShouldNotBeNil)
+		So(remover.DeleteService(svc.Name, svc.Namespace), ShouldBeNil)
+		So(remover.Commit(), ShouldBeNil)
+	})
+}
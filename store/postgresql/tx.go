@@ -41,8 +41,8 @@ func (t *Tx) GetDelegateTx() interface{} {
 	return t.delegateTx
 }
 
+// CreateReadView 将事务切换为可重复读，事务内的所有查询读取同一个快照
+// 需要在事务的第一条查询之前调用
 func (t *Tx) CreateReadView() error {
-	tx := t.delegateTx
-	_, err := tx.Exec("START TRANSACTION WITH CONSISTENT SNAPSHOT")
-	return err
+	return t.delegateTx.createReadView()
 }
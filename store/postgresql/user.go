@@ -49,7 +49,7 @@ var (
 
 type userStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *slavePool
 }
 
 // AddUser 添加用户
@@ -73,7 +73,7 @@ func (u *userStore) AddUser(user *model.User) error {
 
 func (u *userStore) addUser(user *model.User) error {
 
-	tx, err := u.master.Begin()
+	tx, err := u.master.Begin("userStore.addUser")
 	if err != nil {
 		return err
 	}
@@ -128,7 +128,7 @@ func (u *userStore) UpdateUser(user *model.User) error {
 
 func (u *userStore) updateUser(user *model.User) error {
 
-	tx, err := u.master.Begin()
+	tx, err := u.master.Begin("userStore.updateUser")
 	if err != nil {
 		return err
 	}
@@ -193,7 +193,7 @@ func (u *userStore) DeleteUser(user *model.User) error {
 //
 // step 2. Delete the user group associated with this user
 func (u *userStore) deleteUser(user *model.User) error {
-	tx, err := u.master.Begin()
+	tx, err := u.master.Begin("userStore.deleteUser")
 	if err != nil {
 		return err
 	}
@@ -240,7 +240,7 @@ func (u *userStore) deleteUser(user *model.User) error {
 func (u *userStore) GetSubCount(user *model.User) (uint32, error) {
 	var (
 		countSql   = "SELECT COUNT(*) FROM \"user\" WHERE owner = $1 AND flag = 0"
-		count, err = queryEntryCount(u.master, countSql, []interface{}{user.ID})
+		count, err = queryEntryCount(u.master, "userStore.GetSubCount", countSql, []interface{}{user.ID})
 	)
 
 	if err != nil {
@@ -257,7 +257,7 @@ func (u *userStore) GetUser(id string) (*model.User, error) {
 		"u.token, u.token_enable, u.user_type, u.mobile, u.email FROM \"user\" u " +
 		"WHERE u.flag = 0 AND u.id = $1"
 	var (
-		row  = u.master.QueryRow(getSql, id)
+		row  = u.master.QueryRow("userStore.GetUser", getSql, id)
 		user = new(model.User)
 	)
 
@@ -283,7 +283,7 @@ func (u *userStore) GetUserByName(name, ownerId string) (*model.User, error) {
 		"WHERE u.flag = 0 AND u.name = $1 AND u.owner = $2"
 
 	var (
-		row                   = u.master.QueryRow(getSql, name, ownerId)
+		row                   = u.master.QueryRow("userStore.GetUserByName", getSql, name, ownerId)
 		user                  = new(model.User)
 		tokenEnable, userType int
 	)
@@ -329,7 +329,7 @@ func (u *userStore) GetUserByIds(ids []string) ([]*model.User, error) {
 		args = append(args, ids[index])
 	}
 
-	rows, err := u.master.Query(getSql, args...)
+	rows, err := u.master.Query("userStore.GetUserByIds", getSql, args...)
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -408,7 +408,7 @@ func (u *userStore) listUsers(filters map[string]string, offset uint32, limit ui
 		}
 	}
 
-	count, err := queryEntryCount(u.master, countSql, args)
+	count, err := queryEntryCount(u.master, "userStore.listUsers", countSql, args)
 	if err != nil {
 		return 0, nil, store.Error(err)
 	}
@@ -416,7 +416,7 @@ func (u *userStore) listUsers(filters map[string]string, offset uint32, limit ui
 	getSql += fmt.Sprintf(" ORDER BY mtime LIMIT $%d OFFSET $%d", idx, idx+1)
 	getArgs := append(args, limit, offset)
 
-	users, err := u.collectUsers(u.master.Query, getSql, getArgs)
+	users, err := u.collectUsers(u.master.queryHandler("userStore.listUsers"), getSql, getArgs)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -469,7 +469,7 @@ func (u *userStore) listGroupUsers(filters map[string]string, offset uint32, lim
 		idx++
 	}
 
-	count, err := queryEntryCount(u.slave, countSql, args)
+	count, err := queryEntryCount(u.slave.pick(), "userStore.listGroupUsers", countSql, args)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -477,7 +477,7 @@ func (u *userStore) listGroupUsers(filters map[string]string, offset uint32, lim
 	querySql += fmt.Sprintf(" ORDER BY u.mtime LIMIT $%d OFFSET $%d", idx, idx+1)
 	args = append(args, limit, offset)
 
-	users, err := u.collectUsers(u.master.Query, querySql, args)
+	users, err := u.collectUsers(u.master.queryHandler("userStore.listGroupUsers"), querySql, args)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -492,23 +492,26 @@ func (u *userStore) GetUsersForCache(mtime time.Time, firstUpdate bool) ([]*mode
 		"u.token_enable, user_type, u.ctime, u.mtime, u.flag, u.mobile, u.email " +
 		"FROM \"user\" u"
 
+	since := watermarks.since(mtime)
 	if !firstUpdate {
 		querySql += " WHERE u.mtime >= $1 "
-		args = append(args, mtime)
+		args = append(args, since)
 	}
 
-	users, err := u.collectUsers(u.master.Query, querySql, args)
+	users, err := u.collectUsers(u.master.queryHandler("userStore.GetUsersForCache"), querySql, args)
 	if err != nil {
 		return nil, err
 	}
 
-	return users, nil
+	return dedupSlice(watermarks, "user", since, firstUpdate, users, func(user *model.User) (string, string) {
+		return user.ID, rowVersion(user.ModifyTime)
+	}), nil
 }
 
 // collectUsers General query user list
 func (u *userStore) collectUsers(handler QueryHandler, querySql string,
 	args []interface{}) ([]*model.User, error) {
-	rows, err := u.master.Query(querySql, args...)
+	rows, err := u.master.Query("userStore.collectUsers", querySql, args...)
 	if err != nil {
 		log.Error("[Store][User] list user ", zap.String("query sql", querySql), zap.Any("args", args))
 		return nil, store.Error(err)
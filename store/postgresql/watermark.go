@@ -0,0 +1,184 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWatermarkOverlap 增量查询默认的重叠窗口
+	DefaultWatermarkOverlap = time.Second
+)
+
+// watermarks 增量查询的水位线，在 Initialize 时按 watermarkOverlap 配置重新设置
+var watermarks = newWatermarkDedup(DefaultWatermarkOverlap)
+
+// parseWatermarkOverlap 解析增量查询的重叠窗口，为0时只按精确到微秒的 mtime 查询
+func parseWatermarkOverlap(opt interface{}) (time.Duration, error) {
+	if opt == nil {
+		return DefaultWatermarkOverlap, nil
+	}
+	d, err := time.ParseDuration(fmt.Sprintf("%v", opt))
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("config Plugin %s:watermarkOverlap is invalid duration: %v", STORENAME, opt)
+	}
+	return d, nil
+}
+
+// watermarkDedup 增量查询的水位线以及重叠窗口内的去重
+// 写入 mtime 的事务可能晚于比它 mtime 更大的事务提交，增量查询把下界向前放宽一个重叠窗口，
+// 再去掉上一次查询已经返回过的相同版本的数据，每个查询 key 假定只有一个按 mtime 递增拉取的调用方
+type watermarkDedup struct {
+	overlap time.Duration
+	lock    sync.Mutex
+	states  map[string]*watermarkState
+}
+
+// watermarkState 某个增量查询上一次的下界以及返回的数据版本
+type watermarkState struct {
+	since    time.Time
+	versions map[string]string
+}
+
+func newWatermarkDedup(overlap time.Duration) *watermarkDedup {
+	return &watermarkDedup{
+		overlap: overlap,
+		states:  make(map[string]*watermarkState),
+	}
+}
+
+// since 计算增量查询的下界，mtime 精确到微秒后减去重叠窗口，查询条件统一使用 mtime >= since
+func (w *watermarkDedup) since(mtime time.Time) time.Time {
+	since := mtime.Truncate(time.Microsecond).Add(-w.overlap)
+	if since.Before(time.Unix(0, 0)) {
+		return time.Unix(0, 0)
+	}
+	return since
+}
+
+// reset 清理查询 key 的去重记录，首次拉取的结果可能是全量数据，不做记录
+func (w *watermarkDedup) reset(key string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	delete(w.states, key)
+}
+
+// duplicates 返回本次查询结果中上一次已经返回过的相同版本的数据ID，并记录本次查询的结果
+// 只有下界相对上一次严格前进时才去重：调用方只有在消费了上一次的结果后才会推进 mtime，
+// 下界不变说明上一次的结果出错或被丢弃后重试，需要完整返回；去重也只针对修改时间仍在本次重叠窗口内的数据
+func (w *watermarkDedup) duplicates(key string, since time.Time, versions map[string]string) map[string]struct{} {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var dups map[string]struct{}
+	if state, ok := w.states[key]; ok && since.After(state.since) {
+		for id, version := range versions {
+			prev, ok := state.versions[id]
+			if !ok || prev != version {
+				continue
+			}
+			if mtime, ok := versionMtime(prev); !ok || mtime.Before(since) {
+				continue
+			}
+			if dups == nil {
+				dups = make(map[string]struct{})
+			}
+			dups[id] = struct{}{}
+		}
+	}
+	// 本次结果都满足 mtime >= since，不在本次结果中的数据不会再出现在之后的重叠窗口内，只需要保留本次的结果
+	w.states[key] = &watermarkState{since: since, versions: versions}
+	return dups
+}
+
+// dedupSlice 去掉增量查询结果中重复返回的数据，version 返回数据的ID以及版本
+func dedupSlice[T any](w *watermarkDedup, key string, since time.Time, firstUpdate bool, rows []T,
+	version func(T) (string, string)) []T {
+	if firstUpdate {
+		w.reset(key)
+		return rows
+	}
+	versions := make(map[string]string, len(rows))
+	for _, row := range rows {
+		id, ver := version(row)
+		versions[id] = ver
+	}
+	dups := w.duplicates(key, since, versions)
+	if len(dups) == 0 {
+		return rows
+	}
+	out := make([]T, 0, len(rows)-len(dups))
+	for _, row := range rows {
+		if id, _ := version(row); !hasKey(dups, id) {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// dedupMap 去掉以ID为key的增量查询结果中重复返回的数据
+func dedupMap[T any](w *watermarkDedup, key string, since time.Time, firstUpdate bool, rows map[string]T,
+	version func(T) string) map[string]T {
+	if firstUpdate {
+		w.reset(key)
+		return rows
+	}
+	versions := make(map[string]string, len(rows))
+	for id, row := range rows {
+		versions[id] = version(row)
+	}
+	for id := range w.duplicates(key, since, versions) {
+		delete(rows, id)
+	}
+	return rows
+}
+
+func hasKey(set map[string]struct{}, key string) bool {
+	_, ok := set[key]
+	return ok
+}
+
+// rowVersion 数据的版本，由精确到微秒的修改时间以及可选的 revision 组成
+func rowVersion(mtime time.Time, revision ...string) string {
+	ver := fmt.Sprintf("%d", mtime.Truncate(time.Microsecond).UnixMicro())
+	for _, item := range revision {
+		ver += "/" + item
+	}
+	return ver
+}
+
+// versionMtime 解析 rowVersion 中的修改时间
+func versionMtime(version string) (time.Time, bool) {
+	micro, err := strconv.ParseInt(strings.SplitN(version, "/", 2)[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMicro(micro), true
+}
+
+// epochToTime 将 EXTRACT(EPOCH FROM ...) 得到的秒数转换为时间，保留微秒精度
+func epochToTime(epoch float64) time.Time {
+	sec, frac := math.Modf(epoch)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e6))*int64(time.Microsecond))
+}
@@ -0,0 +1,127 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package postgresql
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type watermarkRow struct {
+	id    string
+	mtime time.Time
+}
+
+func watermarkRowVersion(row watermarkRow) (string, string) {
+	return row.id, rowVersion(row.mtime)
+}
+
+func TestWatermarkSince(t *testing.T) {
+	Convey("下界精确到微秒并减去重叠窗口", t, func() {
+		w := newWatermarkDedup(time.Second)
+		mtime := time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC)
+		So(w.since(mtime), ShouldEqual, time.Date(2024, 5, 1, 9, 59, 59, 123456000, time.UTC))
+	})
+	Convey("下界不早于 1970-01-01", t, func() {
+		w := newWatermarkDedup(time.Minute)
+		So(w.since(time.Time{}).Equal(time.Unix(0, 0)), ShouldBeTrue)
+		So(w.since(time.Unix(1, 0)).Equal(time.Unix(0, 0)), ShouldBeTrue)
+	})
+	Convey("解析重叠窗口配置", t, func() {
+		d, err := parseWatermarkOverlap(nil)
+		So(err, ShouldBeNil)
+		So(d, ShouldEqual, DefaultWatermarkOverlap)
+		d, err = parseWatermarkOverlap("500ms")
+		So(err, ShouldBeNil)
+		So(d, ShouldEqual, 500*time.Millisecond)
+		_, err = parseWatermarkOverlap("-1s")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestWatermarkDedup(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	a := watermarkRow{id: "a", mtime: base.Add(100 * time.Millisecond)}
+	b := watermarkRow{id: "b", mtime: base.Add(900 * time.Millisecond)}
+
+	Convey("重叠窗口内重复返回的相同版本被去掉", t, func() {
+		w := newWatermarkDedup(time.Second)
+		since := w.since(base)
+		So(dedupSlice(w, "t", since, false, []watermarkRow{a, b}, watermarkRowVersion), ShouldHaveLength, 2)
+
+		// 下一次按 b 的修改时间拉取，a、b 仍然在重叠窗口内，只返回新提交的 c
+		c := watermarkRow{id: "c", mtime: base.Add(500 * time.Millisecond)}
+		since = w.since(b.mtime)
+		out := dedupSlice(w, "t", since, false, []watermarkRow{a, c, b}, watermarkRowVersion)
+		So(out, ShouldResemble, []watermarkRow{c})
+	})
+	Convey("同一数据的新版本不会被去掉", t, func() {
+		w := newWatermarkDedup(time.Second)
+		dedupSlice(w, "t", w.since(base), false, []watermarkRow{a}, watermarkRowVersion)
+		updated := watermarkRow{id: "a", mtime: a.mtime.Add(time.Microsecond)}
+		So(dedupSlice(w, "t", w.since(a.mtime), false, []watermarkRow{updated}, watermarkRowVersion),
+			ShouldResemble, []watermarkRow{updated})
+	})
+	Convey("相同下界重试时完整返回上一次的结果", t, func() {
+		w := newWatermarkDedup(time.Second)
+		since := w.since(base)
+		So(dedupSlice(w, "t", since, false, []watermarkRow{a, b}, watermarkRowVersion), ShouldHaveLength, 2)
+		// 上一次的结果被丢弃后调用方没有推进 mtime，重试时不能把数据当作重复去掉
+		So(dedupSlice(w, "t", since, false, []watermarkRow{a, b}, watermarkRowVersion), ShouldHaveLength, 2)
+		So(dedupSlice(w, "t", since, false, []watermarkRow{a, b}, watermarkRowVersion), ShouldHaveLength, 2)
+		// 重试成功后推进 mtime，重叠窗口内的数据才会被去重
+		So(dedupSlice(w, "t", w.since(b.mtime), false, []watermarkRow{a, b}, watermarkRowVersion), ShouldBeEmpty)
+	})
+	Convey("只对修改时间在本次重叠窗口内的数据去重", t, func() {
+		w := newWatermarkDedup(time.Second)
+		old := watermarkRow{id: "old", mtime: base.Add(-2 * time.Second)}
+		dedupSlice(w, "t", w.since(base.Add(-3*time.Second)), false, []watermarkRow{old, a}, watermarkRowVersion)
+		So(w.duplicates("t", w.since(base), map[string]string{
+			"old": rowVersion(old.mtime), "a": rowVersion(a.mtime),
+		}), ShouldResemble, map[string]struct{}{"a": {}})
+	})
+	Convey("下界回退以及首次拉取时不去重", t, func() {
+		w := newWatermarkDedup(time.Second)
+		since := w.since(b.mtime)
+		dedupSlice(w, "t", since, false, []watermarkRow{a, b}, watermarkRowVersion)
+		So(dedupSlice(w, "t", w.since(base), false, []watermarkRow{a, b}, watermarkRowVersion), ShouldHaveLength, 2)
+
+		dedupSlice(w, "t", since, true, []watermarkRow{a, b}, watermarkRowVersion)
+		So(dedupSlice(w, "t", since, false, []watermarkRow{a, b}, watermarkRowVersion), ShouldHaveLength, 2)
+	})
+	Convey("不同查询 key 互不影响", t, func() {
+		w := newWatermarkDedup(time.Second)
+		rows := map[string]watermarkRow{"a": a}
+		dedupMap(w, "t1", w.since(base), false, rows, func(row watermarkRow) string { return rowVersion(row.mtime) })
+		out := dedupMap(w, "t2", w.since(a.mtime), false, map[string]watermarkRow{"a": a},
+			func(row watermarkRow) string { return rowVersion(row.mtime) })
+		So(out, ShouldHaveLength, 1)
+		out = dedupMap(w, "t1", w.since(a.mtime), false, map[string]watermarkRow{"a": a},
+			func(row watermarkRow) string { return rowVersion(row.mtime) })
+		So(out, ShouldBeEmpty)
+	})
+}
+
+func TestEpochToTime(t *testing.T) {
+	Convey("保留微秒精度", t, func() {
+		So(epochToTime(1714557600.123456).Equal(time.Unix(1714557600, 123456000)), ShouldBeTrue)
+		So(epochToTime(1714557600).Equal(time.Unix(1714557600, 0)), ShouldBeTrue)
+	})
+}